@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -54,6 +55,26 @@ type Block struct {
 	Unsupported      map[string]interface{} `json:"unsupported,omitempty"`
 }
 
+// MarshalJSON encodes the block, sending an empty object for divider and
+// breadcrumb blocks, which have no configuration but require one
+func (b Block) MarshalJSON() ([]byte, error) {
+	type block Block
+	if b.Type != BlockTypeDivider && b.Type != BlockTypeBreadcrumb {
+		return json.Marshal(block(b))
+	}
+	out := struct {
+		block
+		Divider    *struct{} `json:"divider,omitempty"`
+		Breadcrumb *struct{} `json:"breadcrumb,omitempty"`
+	}{block: block(b)}
+	if b.Type == BlockTypeDivider {
+		out.Divider = &struct{}{}
+	} else {
+		out.Breadcrumb = &struct{}{}
+	}
+	return json.Marshal(out)
+}
+
 // ParagraphBlock represents a paragraph block
 type ParagraphBlock struct {
 	RichText []RichText `json:"rich_text"`
@@ -106,7 +127,9 @@ type TemplateBlock struct {
 
 // SyncedBlock represents a synced block
 type SyncedBlock struct {
-	SyncedFrom *SyncedFrom `json:"synced_from,omitempty"`
+	// SyncedFrom is nil for an original synced block, which Notion expects
+	// to be sent as null
+	SyncedFrom *SyncedFrom `json:"synced_from"`
 	Children   []Block     `json:"children,omitempty"`
 }
 
@@ -119,6 +142,9 @@ type SyncedFrom struct {
 // ChildPageBlock represents a child page block
 type ChildPageBlock struct {
 	Title string `json:"title"`
+	// Children holds the content of the child page when fetched with
+	// GetBlockTree. It is never sent to the API.
+	Children []Block `json:"-"`
 }
 
 // ChildDatabaseBlock represents a child database block
@@ -206,6 +232,234 @@ type LinkToPageBlock struct {
 	DatabaseID string `json:"database_id,omitempty"`
 }
 
+// Children returns the nested children of a block, or nil if the block type
+// cannot have children
+func (b *Block) Children() []Block {
+	switch b.Type {
+	case BlockTypeParagraph:
+		if b.Paragraph != nil {
+			return b.Paragraph.Children
+		}
+	case BlockTypeHeading1:
+		if b.Heading1 != nil {
+			return b.Heading1.Children
+		}
+	case BlockTypeHeading2:
+		if b.Heading2 != nil {
+			return b.Heading2.Children
+		}
+	case BlockTypeHeading3:
+		if b.Heading3 != nil {
+			return b.Heading3.Children
+		}
+	case BlockTypeBulletedListItem:
+		if b.BulletedListItem != nil {
+			return b.BulletedListItem.Children
+		}
+	case BlockTypeNumberedListItem:
+		if b.NumberedListItem != nil {
+			return b.NumberedListItem.Children
+		}
+	case BlockTypeQuote:
+		if b.Quote != nil {
+			return b.Quote.Children
+		}
+	case BlockTypeToDo:
+		if b.ToDo != nil {
+			return b.ToDo.Children
+		}
+	case BlockTypeToggle:
+		if b.Toggle != nil {
+			return b.Toggle.Children
+		}
+	case BlockTypeTemplate:
+		if b.Template != nil {
+			return b.Template.Children
+		}
+	case BlockTypeSynced:
+		if b.Synced != nil {
+			return b.Synced.Children
+		}
+	case BlockTypeCallout:
+		if b.Callout != nil {
+			return b.Callout.Children
+		}
+	case BlockTypeColumnList:
+		if b.ColumnList != nil {
+			return b.ColumnList.Children
+		}
+	case BlockTypeColumn:
+		if b.Column != nil {
+			return b.Column.Children
+		}
+	case BlockTypeTable:
+		if b.Table != nil {
+			return b.Table.Children
+		}
+	case BlockTypeChildPage:
+		if b.ChildPage != nil {
+			return b.ChildPage.Children
+		}
+	}
+	return nil
+}
+
+// SetChildren sets the nested children of a block. It returns false if the
+// block type cannot have children.
+func (b *Block) SetChildren(children []Block) bool {
+	switch b.Type {
+	case BlockTypeParagraph:
+		if b.Paragraph == nil {
+			b.Paragraph = &ParagraphBlock{}
+		}
+		b.Paragraph.Children = children
+	case BlockTypeHeading1:
+		if b.Heading1 == nil {
+			b.Heading1 = &HeadingBlock{}
+		}
+		b.Heading1.Children = children
+	case BlockTypeHeading2:
+		if b.Heading2 == nil {
+			b.Heading2 = &HeadingBlock{}
+		}
+		b.Heading2.Children = children
+	case BlockTypeHeading3:
+		if b.Heading3 == nil {
+			b.Heading3 = &HeadingBlock{}
+		}
+		b.Heading3.Children = children
+	case BlockTypeBulletedListItem:
+		if b.BulletedListItem == nil {
+			b.BulletedListItem = &ListItemBlock{}
+		}
+		b.BulletedListItem.Children = children
+	case BlockTypeNumberedListItem:
+		if b.NumberedListItem == nil {
+			b.NumberedListItem = &ListItemBlock{}
+		}
+		b.NumberedListItem.Children = children
+	case BlockTypeQuote:
+		if b.Quote == nil {
+			b.Quote = &QuoteBlock{}
+		}
+		b.Quote.Children = children
+	case BlockTypeToDo:
+		if b.ToDo == nil {
+			b.ToDo = &ToDoBlock{}
+		}
+		b.ToDo.Children = children
+	case BlockTypeToggle:
+		if b.Toggle == nil {
+			b.Toggle = &ToggleBlock{}
+		}
+		b.Toggle.Children = children
+	case BlockTypeTemplate:
+		if b.Template == nil {
+			b.Template = &TemplateBlock{}
+		}
+		b.Template.Children = children
+	case BlockTypeSynced:
+		if b.Synced == nil {
+			b.Synced = &SyncedBlock{}
+		}
+		b.Synced.Children = children
+	case BlockTypeCallout:
+		if b.Callout == nil {
+			b.Callout = &CalloutBlock{}
+		}
+		b.Callout.Children = children
+	case BlockTypeColumnList:
+		if b.ColumnList == nil {
+			b.ColumnList = &ColumnListBlock{}
+		}
+		b.ColumnList.Children = children
+	case BlockTypeColumn:
+		if b.Column == nil {
+			b.Column = &ColumnBlock{}
+		}
+		b.Column.Children = children
+	case BlockTypeTable:
+		if b.Table == nil {
+			b.Table = &TableBlock{}
+		}
+		b.Table.Children = children
+	case BlockTypeChildPage:
+		if b.ChildPage == nil {
+			b.ChildPage = &ChildPageBlock{}
+		}
+		b.ChildPage.Children = children
+	default:
+		return false
+	}
+	return true
+}
+
+// WithChildren adds children to a block and returns it, so that block trees
+// can be built fluently. Children are ignored for block types that cannot
+// have them.
+func (b *Block) WithChildren(children ...*Block) *Block {
+	all := append([]Block(nil), b.Children()...)
+	for _, child := range children {
+		if child != nil {
+			all = append(all, *child)
+		}
+	}
+	b.SetChildren(all)
+	return b
+}
+
+// WithColor sets the color of a block and returns it. The color is ignored
+// for block types without one.
+func (b *Block) WithColor(color string) *Block {
+	switch b.Type {
+	case BlockTypeParagraph:
+		if b.Paragraph != nil {
+			b.Paragraph.Color = color
+		}
+	case BlockTypeHeading1:
+		if b.Heading1 != nil {
+			b.Heading1.Color = color
+		}
+	case BlockTypeHeading2:
+		if b.Heading2 != nil {
+			b.Heading2.Color = color
+		}
+	case BlockTypeHeading3:
+		if b.Heading3 != nil {
+			b.Heading3.Color = color
+		}
+	case BlockTypeBulletedListItem:
+		if b.BulletedListItem != nil {
+			b.BulletedListItem.Color = color
+		}
+	case BlockTypeNumberedListItem:
+		if b.NumberedListItem != nil {
+			b.NumberedListItem.Color = color
+		}
+	case BlockTypeQuote:
+		if b.Quote != nil {
+			b.Quote.Color = color
+		}
+	case BlockTypeToDo:
+		if b.ToDo != nil {
+			b.ToDo.Color = color
+		}
+	case BlockTypeToggle:
+		if b.Toggle != nil {
+			b.Toggle.Color = color
+		}
+	case BlockTypeCallout:
+		if b.Callout != nil {
+			b.Callout.Color = color
+		}
+	case BlockTypeTableOfContents:
+		if b.TableOfContents != nil {
+			b.TableOfContents.Color = color
+		}
+	}
+	return b
+}
+
 // BlocksListResponse represents a list of blocks
 type BlocksListResponse struct {
 	ListResponse
@@ -215,6 +469,9 @@ type BlocksListResponse struct {
 // AppendBlockChildrenRequest represents a request to append children to a block
 type AppendBlockChildrenRequest struct {
 	Children []Block `json:"children"`
+	// After is the ID of an existing child to insert the new children after.
+	// If empty they are appended at the end.
+	After string `json:"after,omitempty"`
 }
 
 // UpdateBlockRequest represents a request to update a block
@@ -280,24 +537,18 @@ func (c *Client) GetBlockWithChildren(ctx context.Context, blockID string) (*Blo
 
 // GetAllBlockChildren retrieves all children of a block, handling pagination automatically
 func (c *Client) GetAllBlockChildren(ctx context.Context, blockID string) ([]Block, error) {
-	var allChildren []Block
-	startCursor := ""
+	return c.BlockChildrenPaginator(blockID, 100).All(ctx)
+}
 
-	for {
-		resp, err := c.GetBlockChildren(ctx, blockID, startCursor, 100)
+// BlockChildrenPaginator returns a paginator over the children of a block
+func (c *Client) BlockChildrenPaginator(blockID string, pageSize int) *Paginator[Block] {
+	return NewPaginator(func(ctx context.Context, cursor string) ([]Block, ListResponse, error) {
+		resp, err := c.GetBlockChildren(ctx, blockID, cursor, pageSize)
 		if err != nil {
-			return nil, err
-		}
-
-		allChildren = append(allChildren, resp.Results...)
-
-		if !resp.HasMore {
-			break
+			return nil, ListResponse{}, err
 		}
-		startCursor = resp.NextCursor
-	}
-
-	return allChildren, nil
+		return resp.Results, resp.ListResponse, nil
+	})
 }
 
 // GetBlockChildrenWithTables retrieves children of a block and populates table children
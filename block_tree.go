@@ -0,0 +1,372 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// DefaultBlockTreeConcurrency is the default number of concurrent requests made by GetBlockTree
+const DefaultBlockTreeConcurrency = 3
+
+// BlockTreeOptions configures GetBlockTree
+type BlockTreeOptions struct {
+	// MaxDepth limits how many levels of children are fetched. 1 fetches
+	// only the direct children of the root. Zero means no limit.
+	MaxDepth int
+	// Concurrency is the maximum number of requests in flight at once.
+	// Zero means DefaultBlockTreeConcurrency.
+	Concurrency int
+	// SkipChildPages does not fetch the content of sub-pages. Their
+	// child_page blocks stay in the tree with their titles, but without
+	// Children.
+	SkipChildPages bool
+	// SkipChildDatabases does not fetch the content of inline databases.
+	// Their child_database blocks stay in the tree with their titles.
+	// Database rows are pages rather than blocks, so GetBlockTree never
+	// fetches them either way.
+	SkipChildDatabases bool
+}
+
+// GetBlockTree retrieves the children of a block recursively, following
+// HasChildren for every block type and filling in the Children of each
+// block. Subtrees are fetched in parallel with at most opts.Concurrency
+// requests in flight. The first error cancels all outstanding requests.
+func (c *Client) GetBlockTree(ctx context.Context, blockID string, opts *BlockTreeOptions) ([]Block, error) {
+	if opts == nil {
+		opts = &BlockTreeOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBlockTreeConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	f := &treeFetcher{
+		client: c,
+		opts:   opts,
+		sem:    make(chan struct{}, concurrency),
+		cancel: cancel,
+	}
+	blocks := f.fetch(ctx, blockID, 1)
+	f.wg.Wait()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	return blocks, nil
+}
+
+// treeFetcher holds the state shared by the goroutines of a GetBlockTree call
+type treeFetcher struct {
+	client *Client
+	opts   *BlockTreeOptions
+	sem    chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	err    error
+}
+
+// fetch retrieves the children of blockID at the given depth and starts
+// goroutines fetching their subtrees. The subtrees are complete once wg is done.
+func (f *treeFetcher) fetch(ctx context.Context, blockID string, depth int) []Block {
+	select {
+	case f.sem <- struct{}{}:
+	case <-ctx.Done():
+		f.fail(ctx.Err())
+		return nil
+	}
+	children, err := f.client.GetAllBlockChildren(ctx, blockID)
+	<-f.sem
+	if err != nil {
+		f.fail(err)
+		return nil
+	}
+
+	blocks := children
+	if f.opts.MaxDepth > 0 && depth >= f.opts.MaxDepth {
+		return blocks
+	}
+
+	for i := range blocks {
+		block := &blocks[i]
+		if !block.HasChildren || !block.SetChildren(block.Children()) || f.skip(block) {
+			continue
+		}
+		f.wg.Add(1)
+		go func() {
+			defer f.wg.Done()
+			if children := f.fetch(ctx, block.ID, depth+1); children != nil {
+				block.SetChildren(children)
+			}
+		}()
+	}
+
+	return blocks
+}
+
+// skip reports whether the options leave out the content of block
+func (f *treeFetcher) skip(block *Block) bool {
+	return (f.opts.SkipChildPages && block.Type == BlockTypeChildPage) ||
+		(f.opts.SkipChildDatabases && block.Type == BlockTypeChildDatabase)
+}
+
+// fail records the first error and cancels the remaining requests
+func (f *treeFetcher) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil {
+		f.err = err
+		f.cancel()
+	}
+}
+
+// MaxBlockChildren is the maximum number of children Notion accepts in a single append request
+const MaxBlockChildren = 100
+
+// maxInlineNesting is the number of nesting levels Notion accepts in a single append request
+const maxInlineNesting = 2
+
+// AppendBlockTree appends blocks to a parent, working around the limits of
+// AppendBlockChildren. Blocks are sent in batches of at most
+// MaxBlockChildren top-level blocks, MaxBlocksPerRequest blocks in total and
+// MaxRequestSize bytes, and nested children are created in follow-up calls
+// under the IDs returned for their parents. Tables and column lists, which
+// Notion requires to be created with their children, are sent with as many
+// children as fit; the rest are appended afterwards. It returns the created
+// blocks with their Children filled in.
+func (c *Client) AppendBlockTree(ctx context.Context, parentID string, blocks []Block) ([]Block, error) {
+	return c.appendTree(ctx, parentID, "", blocks)
+}
+
+// InsertBlocksAfter inserts blocks into a parent directly after one of its
+// existing children. Like AppendBlockTree it splits large and deeply nested
+// trees into several requests, inserting each batch after the last block of
+// the previous one so the order is kept.
+func (c *Client) InsertBlocksAfter(ctx context.Context, parentID, afterBlockID string, blocks []Block) ([]Block, error) {
+	return c.appendTree(ctx, parentID, afterBlockID, blocks)
+}
+
+// appendTree appends blocks to a parent after the given child, or at the end if after is empty
+func (c *Client) appendTree(ctx context.Context, parentID, after string, blocks []Block) ([]Block, error) {
+	created := make([]Block, 0, len(blocks))
+	for start := 0; start < len(blocks); {
+		budget := newAppendBudget()
+		var sent []Block
+		for end := start; end < len(blocks) && len(sent) < MaxBlockChildren; end++ {
+			block, ok := inlineChildren(blocks[end], 0, budget)
+			if !ok {
+				break
+			}
+			sent = append(sent, block)
+		}
+		if len(sent) == 0 {
+			return created, fmt.Errorf("failed to append block %d: block is too large for a single request", start)
+		}
+		end := start + len(sent)
+
+		resp, err := c.AppendBlockChildren(ctx, parentID, &AppendBlockChildrenRequest{Children: sent, After: after})
+		if err != nil {
+			return created, fmt.Errorf("failed to append blocks %d-%d: %w", start, end-1, err)
+		}
+		if len(resp.Results) != len(sent) {
+			return created, fmt.Errorf("failed to append blocks %d-%d: expected %d results, got %d", start, end-1, len(sent), len(resp.Results))
+		}
+
+		for i := range resp.Results {
+			block := resp.Results[i]
+			if err := c.completeChildren(ctx, &block, blocks[start+i], sent[i]); err != nil {
+				return created, err
+			}
+			created = append(created, block)
+		}
+		if after != "" {
+			after = created[len(created)-1].ID
+		}
+		start = end
+	}
+	return created, nil
+}
+
+// completeChildren creates the children of orig that were not sent inline
+// under the created block, and fills in the created block's children. sent
+// is the copy of orig that was sent in the append request.
+func (c *Client) completeChildren(ctx context.Context, created *Block, orig, sent Block) error {
+	children := orig.Children()
+	if len(children) == 0 {
+		return nil
+	}
+
+	var result []Block
+	inline := sent.Children()
+	if len(inline) > 0 {
+		existing, err := c.GetAllBlockChildren(ctx, created.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch children of %s: %w", created.ID, err)
+		}
+		if len(existing) < len(inline) {
+			return fmt.Errorf("expected %d children of %s, got %d", len(inline), created.ID, len(existing))
+		}
+		for i := range inline {
+			child := existing[i]
+			if err := c.completeChildren(ctx, &child, children[i], inline[i]); err != nil {
+				return err
+			}
+			result = append(result, child)
+		}
+	}
+
+	if len(inline) < len(children) {
+		rest, err := c.AppendBlockTree(ctx, created.ID, children[len(inline):])
+		result = append(result, rest...)
+		if err != nil {
+			created.SetChildren(result)
+			return err
+		}
+	}
+
+	created.SetChildren(result)
+	return nil
+}
+
+// appendRequestOverhead is the room left in MaxRequestSize for the parts of
+// an append request that are not blocks, such as "after"
+const appendRequestOverhead = 1000
+
+// appendBudget tracks the blocks and bytes left in an append request
+type appendBudget struct {
+	blocks int
+	bytes  int
+}
+
+func newAppendBudget() *appendBudget {
+	return &appendBudget{blocks: MaxBlocksPerRequest, bytes: MaxRequestSize - appendRequestOverhead}
+}
+
+// take reserves room for a block of the given encoded size, reporting
+// whether it fits
+func (b *appendBudget) take(size int) bool {
+	// Separators and the "children" key of the parent
+	size += len(`,"children":[]`)
+	if b.blocks < 1 || b.bytes < size {
+		return false
+	}
+	b.blocks--
+	b.bytes -= size
+	return true
+}
+
+// inlineChildren returns a copy of a block for an append request at the
+// given nesting level, keeping only the children that must be sent inline
+// and fit in the budget. It reports false, leaving the budget untouched,
+// when the block does not fit with the children Notion requires.
+func inlineChildren(block Block, level int, budget *appendBudget) (Block, bool) {
+	saved := *budget
+	self := cloneWithChildren(block, nil)
+	data, err := json.Marshal(self)
+	if err != nil || !budget.take(len(data)) {
+		*budget = saved
+		return Block{}, false
+	}
+
+	children := block.Children()
+	var inline []Block
+	for i := 0; i < inlinedCount(block, level); i++ {
+		child, ok := inlineChildren(children[i], level+1, budget)
+		if !ok {
+			break
+		}
+		inline = append(inline, child)
+	}
+	if len(inline) < requiredChildren(block, level) {
+		*budget = saved
+		return Block{}, false
+	}
+	return cloneWithChildren(block, inline), true
+}
+
+// inlinedCount returns how many children of a block are sent inline at most
+// when it is appended at the given nesting level
+func inlinedCount(block Block, level int) int {
+	switch block.Type {
+	case BlockTypeTable, BlockTypeColumnList, BlockTypeColumn:
+	default:
+		return 0
+	}
+	if level >= maxInlineNesting {
+		return 0
+	}
+	n := len(block.Children())
+	if n > MaxBlockChildren {
+		n = MaxBlockChildren
+	}
+	return n
+}
+
+// requiredChildren returns how many children Notion requires a block to be
+// created with: a row for tables, two columns for column lists and a block
+// for columns
+func requiredChildren(block Block, level int) int {
+	required := 1
+	if block.Type == BlockTypeColumnList {
+		required = 2
+	}
+	if n := inlinedCount(block, level); n < required {
+		return n
+	}
+	return required
+}
+
+// cloneWithChildren returns a copy of a block with different children,
+// leaving the original block untouched
+func cloneWithChildren(block Block, children []Block) Block {
+	switch block.Type {
+	case BlockTypeParagraph:
+		block.Paragraph = clonePtr(block.Paragraph)
+	case BlockTypeHeading1:
+		block.Heading1 = clonePtr(block.Heading1)
+	case BlockTypeHeading2:
+		block.Heading2 = clonePtr(block.Heading2)
+	case BlockTypeHeading3:
+		block.Heading3 = clonePtr(block.Heading3)
+	case BlockTypeBulletedListItem:
+		block.BulletedListItem = clonePtr(block.BulletedListItem)
+	case BlockTypeNumberedListItem:
+		block.NumberedListItem = clonePtr(block.NumberedListItem)
+	case BlockTypeQuote:
+		block.Quote = clonePtr(block.Quote)
+	case BlockTypeToDo:
+		block.ToDo = clonePtr(block.ToDo)
+	case BlockTypeToggle:
+		block.Toggle = clonePtr(block.Toggle)
+	case BlockTypeTemplate:
+		block.Template = clonePtr(block.Template)
+	case BlockTypeSynced:
+		block.Synced = clonePtr(block.Synced)
+	case BlockTypeCallout:
+		block.Callout = clonePtr(block.Callout)
+	case BlockTypeColumnList:
+		block.ColumnList = clonePtr(block.ColumnList)
+	case BlockTypeColumn:
+		block.Column = clonePtr(block.Column)
+	case BlockTypeTable:
+		block.Table = clonePtr(block.Table)
+	case BlockTypeChildPage:
+		block.ChildPage = clonePtr(block.ChildPage)
+	}
+	block.SetChildren(children)
+	return block
+}
+
+// clonePtr returns a pointer to a copy of *p, or nil if p is nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
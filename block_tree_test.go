@@ -0,0 +1,393 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockTreeServer serves block children from a map of block ID to results JSON
+func blockTreeServer(t *testing.T, children map[string]string, handle func(id string)) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/blocks/"), "/children")
+		if handle != nil {
+			handle(id)
+		}
+		results, ok := children[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"object":"error","status":404,"code":"object_not_found","message":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"list","results":[` + results + `],"has_more":false}`))
+	}))
+}
+
+func TestGetBlockTree(t *testing.T) {
+	server := blockTreeServer(t, map[string]string{
+		"root": `{"id":"toggle","type":"toggle","has_children":true,"toggle":{"rich_text":[]}},
+			{"id":"table","type":"table","has_children":true,"table":{"table_width":1}},
+			{"id":"page","type":"child_page","has_children":true,"child_page":{"title":"Sub"}},
+			{"id":"para","type":"paragraph","has_children":false,"paragraph":{"rich_text":[]}}`,
+		"toggle": `{"id":"item","type":"bulleted_list_item","has_children":true,"bulleted_list_item":{"rich_text":[]}}`,
+		"item":   `{"id":"leaf","type":"paragraph","has_children":false,"paragraph":{"rich_text":[]}}`,
+		"table":  `{"id":"row","type":"table_row","has_children":false,"table_row":{"cells":[]}}`,
+		"page":   `{"id":"inner","type":"paragraph","has_children":false,"paragraph":{"rich_text":[]}}`,
+	}, nil)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	blocks, err := client.GetBlockTree(context.Background(), "root", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(blocks) != 4 {
+		t.Fatalf("Expected 4 blocks, got %d", len(blocks))
+	}
+
+	items := blocks[0].Children()
+	if len(items) != 1 || items[0].ID != "item" {
+		t.Fatalf("Expected toggle children [item], got %+v", items)
+	}
+	if leaves := items[0].Children(); len(leaves) != 1 || leaves[0].ID != "leaf" {
+		t.Errorf("Expected list item children [leaf], got %+v", leaves)
+	}
+	if rows := blocks[1].Table.Children; len(rows) != 1 || rows[0].ID != "row" {
+		t.Errorf("Expected table rows [row], got %+v", rows)
+	}
+	if inner := blocks[2].ChildPage.Children; len(inner) != 1 || inner[0].ID != "inner" {
+		t.Errorf("Expected child page content [inner], got %+v", inner)
+	}
+}
+
+func TestGetBlockTreeOptions(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	server := blockTreeServer(t, map[string]string{
+		"root": `{"id":"toggle","type":"toggle","has_children":true,"toggle":{"rich_text":[]}},
+			{"id":"page","type":"child_page","has_children":true,"child_page":{"title":"Sub"}},
+			{"id":"db","type":"child_database","has_children":false,"child_database":{"title":"DB"}}`,
+		"toggle": `{"id":"item","type":"bulleted_list_item","has_children":true,"bulleted_list_item":{"rich_text":[]}}`,
+	}, func(id string) {
+		mu.Lock()
+		fetched = append(fetched, id)
+		mu.Unlock()
+	})
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	blocks, err := client.GetBlockTree(context.Background(), "root", &BlockTreeOptions{
+		MaxDepth:           2,
+		SkipChildPages:     true,
+		SkipChildDatabases: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(blocks) != 3 || blocks[0].ID != "toggle" {
+		t.Fatalf("Expected the toggle, child page and child database blocks, got %+v", blocks)
+	}
+	if page := blocks[1].ChildPage; page == nil || page.Title != "Sub" || page.Children != nil {
+		t.Errorf("Expected the child page to be kept without its content, got %+v", page)
+	}
+	if db := blocks[2].ChildDatabase; db == nil || db.Title != "DB" {
+		t.Errorf("Expected the child database to be kept, got %+v", db)
+	}
+	if items := blocks[0].Children(); len(items) != 1 || items[0].Children() != nil {
+		t.Errorf("Expected the list item's children not to be fetched, got %+v", items)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("Expected 2 requests, got %v", fetched)
+	}
+}
+
+func TestGetBlockTreeConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	root := make([]string, 10)
+	children := map[string]string{}
+	for i := range root {
+		id := string(rune('a' + i))
+		root[i] = `{"id":"` + id + `","type":"toggle","has_children":true,"toggle":{"rich_text":[]}}`
+		children[id] = `{"id":"` + id + `-child","type":"paragraph","has_children":false,"paragraph":{"rich_text":[]}}`
+	}
+	children["root"] = strings.Join(root, ",")
+
+	server := blockTreeServer(t, children, func(id string) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	})
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	blocks, err := client.GetBlockTree(context.Background(), "root", &BlockTreeOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, block := range blocks {
+		if len(block.Children()) != 1 {
+			t.Errorf("Expected block %s to have 1 child, got %d", block.ID, len(block.Children()))
+		}
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestGetBlockTreeError(t *testing.T) {
+	server := blockTreeServer(t, map[string]string{
+		"root": `{"id":"missing","type":"toggle","has_children":true,"toggle":{"rich_text":[]}}`,
+	}, nil)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	_, err := client.GetBlockTree(context.Background(), "root", nil)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// appendServer stores appended blocks in memory and rejects requests that
+// exceed Notion's limits on children, nesting, blocks and size
+type appendServer struct {
+	mu       sync.Mutex
+	children map[string][]Block
+	nextID   int
+	requests int
+}
+
+// countBlocks returns the number of blocks in a tree
+func countBlocks(blocks []Block) int {
+	n := len(blocks)
+	for i := range blocks {
+		n += countBlocks(blocks[i].Children())
+	}
+	return n
+}
+
+func (s *appendServer) store(parentID, after string, blocks []Block, level int) ([]Block, error) {
+	if len(blocks) > MaxBlockChildren {
+		return nil, fmt.Errorf("too many children: %d", len(blocks))
+	}
+	if level > maxInlineNesting {
+		return nil, fmt.Errorf("too deeply nested")
+	}
+	stored := make([]Block, len(blocks))
+	for i, block := range blocks {
+		s.nextID++
+		block.ID = fmt.Sprintf("b%d", s.nextID)
+		children := block.Children()
+		block.HasChildren = len(children) > 0
+		if _, err := s.store(block.ID, "", children, level+1); err != nil {
+			return nil, err
+		}
+		block.SetChildren(nil)
+		stored[i] = block
+	}
+	existing := s.children[parentID]
+	at := len(existing)
+	if after != "" {
+		at = -1
+		for i, block := range existing {
+			if block.ID == after {
+				at = i + 1
+			}
+		}
+		if at < 0 {
+			return nil, fmt.Errorf("block %s is not a child of %s", after, parentID)
+		}
+	}
+	s.children[parentID] = append(append(append([]Block{}, existing[:at]...), stored...), existing[at:]...)
+	return stored, nil
+}
+
+func (s *appendServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/blocks/"), "/children")
+
+	results := s.children[id]
+	if r.Method == "PATCH" {
+		s.requests++
+		body, _ := io.ReadAll(r.Body)
+		var req AppendBlockChildrenRequest
+		json.Unmarshal(body, &req)
+		blocks := countBlocks(req.Children)
+		stored, err := s.store(id, req.After, req.Children, 0)
+		if blocks > MaxBlocksPerRequest {
+			err = fmt.Errorf("request has %d blocks", blocks)
+		} else if len(body) > MaxRequestSize {
+			err = fmt.Errorf("request body is %d bytes", len(body))
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"object":"error","status":400,"code":"validation_error","message":%q}`, err.Error())
+			return
+		}
+		results = stored
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"object": "list", "results": results, "has_more": false})
+}
+
+func paragraphs(prefix string, n int) []Block {
+	blocks := make([]Block, n)
+	for i := range blocks {
+		blocks[i] = *NewParagraphBlock([]RichText{NewText(fmt.Sprintf("%s%d", prefix, i))})
+	}
+	return blocks
+}
+
+func TestAppendBlockTree(t *testing.T) {
+	server := &appendServer{children: map[string][]Block{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	// A list nested four levels deep, a table with more rows than fit in
+	// one request and more top level blocks than fit in one request
+	deep := *NewBulletedListItemBlock([]RichText{NewText("level 4")})
+	for _, name := range []string{"level 3", "level 2", "level 1"} {
+		parent := NewBulletedListItemBlock([]RichText{NewText(name)})
+		parent.SetChildren([]Block{deep})
+		deep = *parent
+	}
+	table := NewTableBlock(1, false, false)
+	for i := 0; i < 150; i++ {
+		table.Table.Children = append(table.Table.Children, *NewTableRowBlock([][]RichText{{NewText(fmt.Sprint(i))}}))
+	}
+	blocks := append([]Block{deep, *table}, paragraphs("p", 150)...)
+
+	client := NewClient("key", WithBaseURL(ts.URL))
+	created, err := client.AppendBlockTree(context.Background(), "page", blocks)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(created) != 152 || len(server.children["page"]) != 152 {
+		t.Fatalf("Expected 152 blocks, got %d created and %d stored", len(created), len(server.children["page"]))
+	}
+	if PlainText(created[151].Paragraph.RichText) != "p149" {
+		t.Errorf("Expected order to be kept, got %q last", PlainText(created[151].Paragraph.RichText))
+	}
+
+	block := created[0]
+	for depth := 1; depth < 4; depth++ {
+		children := block.Children()
+		if len(children) != 1 || children[0].ID == "" {
+			t.Fatalf("Expected one created child at depth %d, got %+v", depth, children)
+		}
+		if stored := server.children[block.ID]; len(stored) != 1 || stored[0].ID != children[0].ID {
+			t.Fatalf("Expected the child at depth %d to be stored under %s", depth, block.ID)
+		}
+		block = children[0]
+	}
+
+	rows := created[1].Table.Children
+	if len(rows) != 150 || len(server.children[created[1].ID]) != 150 {
+		t.Fatalf("Expected 150 table rows, got %d", len(rows))
+	}
+	if PlainText(rows[120].TableRow.Cells[0]) != "120" || rows[120].ID == "" {
+		t.Errorf("Expected row 120 with an ID, got %+v", rows[120])
+	}
+
+	if len(blocks[0].BulletedListItem.Children) != 1 || len(table.Table.Children) != 150 {
+		t.Error("Expected the input blocks not to be modified")
+	}
+}
+
+func TestAppendBlockTreeRequestLimits(t *testing.T) {
+	server := &appendServer{children: map[string][]Block{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	// 100 tables of 20 rows are 2100 blocks, more than one request holds
+	var blocks []Block
+	for i := 0; i < 100; i++ {
+		table := NewTableBlock(1, false, false)
+		for j := 0; j < 20; j++ {
+			table.Table.Children = append(table.Table.Children, *NewTableRowBlock([][]RichText{{NewText(fmt.Sprintf("%d.%d", i, j))}}))
+		}
+		blocks = append(blocks, *table)
+	}
+	// Large rows that only fit a few at a time in MaxRequestSize
+	large := NewTableBlock(1, false, false)
+	for j := 0; j < 20; j++ {
+		large.Table.Children = append(large.Table.Children, *NewTableRowBlock([][]RichText{largeText(100)}))
+	}
+	blocks = append(blocks, *large)
+
+	client := NewClient("key", WithBaseURL(ts.URL))
+	created, err := client.AppendBlockTree(context.Background(), "page", blocks)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(created) != 101 {
+		t.Fatalf("Expected 101 tables, got %d", len(created))
+	}
+	for i, table := range created {
+		rows := server.children[table.ID]
+		if len(rows) != 20 || len(table.Table.Children) != 20 {
+			t.Fatalf("Expected table %d to have 20 rows, got %d stored and %d returned", i, len(rows), len(table.Table.Children))
+		}
+	}
+	if got := PlainText(created[99].Table.Children[19].TableRow.Cells[0]); got != "99.19" {
+		t.Errorf("Expected the last row of table 99 to be 99.19, got %q", got)
+	}
+	if server.requests < 3 {
+		t.Errorf("Expected the tables to be split over several requests, got %d", server.requests)
+	}
+}
+
+// largeText returns n rich text elements of MaxRichTextLength characters
+func largeText(n int) []RichText {
+	richText := make([]RichText, n)
+	for i := range richText {
+		richText[i] = NewText(strings.Repeat("x", MaxRichTextLength))
+	}
+	return richText
+}
+
+func TestInsertBlocksAfter(t *testing.T) {
+	server := &appendServer{children: map[string][]Block{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client := NewClient("key", WithBaseURL(ts.URL))
+	ctx := context.Background()
+	existing, err := client.AppendBlockTree(ctx, "page", paragraphs("old", 2))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	inserted, err := client.InsertBlocksAfter(ctx, "page", existing[0].ID, paragraphs("new", 150))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(inserted) != 150 {
+		t.Fatalf("Expected 150 inserted blocks, got %d", len(inserted))
+	}
+
+	var texts []string
+	for _, block := range server.children["page"] {
+		texts = append(texts, PlainText(block.Paragraph.RichText))
+	}
+	if len(texts) != 152 || texts[0] != "old0" || texts[1] != "new0" || texts[100] != "new99" ||
+		texts[101] != "new100" || texts[150] != "new149" || texts[151] != "old1" {
+		t.Errorf("Expected the new blocks in order between old0 and old1, got %v", texts)
+	}
+}
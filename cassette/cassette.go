@@ -0,0 +1,301 @@
+// Package cassette records Notion API interactions to a JSON file and
+// replays them, so integration tests can run offline once recorded.
+//
+// A Recorder is an http.RoundTripper; plug it into a client with
+// notion.WithHTTPClient:
+//
+//	rec, err := cassette.New("testdata/pages.json", cassette.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Save()
+//	client := notion.NewClient(token, notion.WithHTTPClient(rec.Client()))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays interactions
+type Mode int
+
+const (
+	// ModeRecord sends requests to the API and records every interaction
+	ModeRecord Mode = iota
+	// ModeReplay serves responses from the cassette without sending any
+	// request, failing requests that match no recorded interaction
+	ModeReplay
+)
+
+// redacted replaces the value of sensitive headers in recorded requests
+const redacted = "REDACTED"
+
+// redactedHeaders are never written to a cassette
+var redactedHeaders = []string{"Authorization"}
+
+// ErrUnmatchedRequest is returned in replay mode for requests that match no
+// unused interaction in the cassette
+var ErrUnmatchedRequest = errors.New("cassette: no recorded interaction matches request")
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. Path includes the query string; Body is
+// the request body with JSON normalized.
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response. JSON bodies are stored as JSON for
+// readability, other bodies as text.
+type Response struct {
+	Status int             `json:"status"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// cassette is the file format
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder records or replays HTTP interactions
+type Recorder struct {
+	// Transport sends requests in record mode. Nil means http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New creates a recorder for the cassette at path. In replay mode the
+// cassette must exist; in record mode it is replaced by Save.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	for i := range c.Interactions {
+		c.Interactions[i].Request.Body = normalizeBody([]byte(c.Interactions[i].Request.Body))
+	}
+	r.interactions = c.Interactions
+	r.used = make([]bool, len(c.Interactions))
+	return r, nil
+}
+
+// Mode returns the mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an HTTP client that sends requests through the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip records or replays a request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, body, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	// The body has been consumed, send a copy of the request with a fresh one
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return r.record(out, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := Response{Status: resp.StatusCode, Header: resp.Header.Clone()}
+	if json.Valid(body) {
+		response.Body = append(json.RawMessage(nil), body...)
+	} else {
+		response.Text = string(body)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{Request: recorded, Response: response})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching the
+// request, so repeated identical requests replay in recorded order
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !interaction.Request.matches(recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.Body == nil {
+			body = []byte(interaction.Response.Text)
+		}
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	body := recorded.Body
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+	return nil, fmt.Errorf("%w: %s %s %s (cassette %s)", ErrUnmatchedRequest, recorded.Method, recorded.Path, body, r.path)
+}
+
+// Unused returns the recorded interactions that have not been replayed
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing in
+// replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mu.Lock()
+	c := cassette{Interactions: r.interactions}
+	if c.Interactions == nil {
+		c.Interactions = []Interaction{}
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// newRequest captures a request for recording and matching, returning the
+// body it reads
+func newRequest(req *http.Request) (Request, []byte, error) {
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Header: req.Header.Clone(),
+	}
+	if query := req.URL.Query(); len(query) > 0 {
+		recorded.Path += "?" + query.Encode()
+	}
+	for _, name := range redactedHeaders {
+		if recorded.Header.Get(name) != "" {
+			recorded.Header.Set(name, redacted)
+		}
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return recorded, nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return recorded, nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	recorded.Body = normalizeBody(body)
+	return recorded, body, nil
+}
+
+// normalizeBody returns JSON bodies with sorted keys and no insignificant
+// whitespace, so that equivalent bodies match
+func normalizeBody(body []byte) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return strings.TrimSpace(string(body))
+	}
+	return string(normalized)
+}
+
+// matches reports whether two requests have the same method, path and body
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method && r.Path == other.Path && r.Body == other.Body
+}
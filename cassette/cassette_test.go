@@ -0,0 +1,98 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wujie1993/go-notion"
+	"github.com/wujie1993/go-notion/notiontest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "search.json")
+
+	server := notiontest.NewServer()
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client := notion.NewClient("secret_token", notion.WithBaseURL(server.URL), notion.WithHTTPClient(rec.Client()))
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := client.Search(ctx, &notion.SearchRequest{Query: "plan", PageSize: 10}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = client.GetPage(ctx, "00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, notion.ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the cassette to be written, got %v", err)
+	}
+	if strings.Contains(string(data), "secret_token") || !strings.Contains(string(data), redacted) {
+		t.Error("Expected the Authorization header to be redacted")
+	}
+
+	// Replay against a closed server, with the body keys in another order
+	rec, err = New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	client = notion.NewClient("other_token", notion.WithBaseURL(server.URL), notion.WithHTTPClient(rec.Client()))
+	replayed, err := client.GetMe(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if replayed.ID != me.ID {
+		t.Errorf("Expected the recorded user %s, got %s", me.ID, replayed.ID)
+	}
+	if _, err := client.Search(ctx, &notion.SearchRequest{PageSize: 10, Query: "plan"}); err != nil {
+		t.Errorf("Expected the search to replay, got %v", err)
+	}
+	_, err = client.GetPage(ctx, "00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, notion.ErrNotFound) {
+		t.Errorf("Expected the recorded error to replay, got %v", err)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("Expected every interaction to be used, got %d unused", len(unused))
+	}
+
+	// Each interaction replays once and unknown requests fail
+	if _, err := client.GetMe(ctx); !errors.Is(err, ErrUnmatchedRequest) {
+		t.Errorf("Expected ErrUnmatchedRequest for a repeated request, got %v", err)
+	}
+	_, err = client.Search(ctx, &notion.SearchRequest{Query: "other"})
+	if !errors.Is(err, ErrUnmatchedRequest) || !strings.Contains(err.Error(), `"query":"other"`) {
+		t.Errorf("Expected ErrUnmatchedRequest describing the request, got %v", err)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("Expected an error for a missing cassette")
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	a := normalizeBody([]byte(`{"b": 1, "a": {"y": [1, 2], "x": 1.50}}`))
+	b := normalizeBody([]byte(`{"a":{"x":1.50,"y":[1,2]},"b":1}`))
+	if a != b {
+		t.Errorf("Expected equivalent JSON to normalize equally, got %s and %s", a, b)
+	}
+	if got := normalizeBody([]byte(" not json \n")); got != "not json" {
+		t.Errorf("Expected non-JSON bodies to be trimmed, got %q", got)
+	}
+}
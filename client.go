@@ -19,10 +19,16 @@ const (
 
 // Client represents a Notion API client
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	version    string
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	version     string
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	middleware  []Middleware
+	handler     Handler
+
+	validateRequests bool
 }
 
 // ClientOption is a function that configures a Client
@@ -49,6 +55,28 @@ func WithVersion(version string) ClientOption {
 	}
 }
 
+// WithRetryPolicy enables automatic retries of failed requests
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests on average,
+// allowing bursts of up to burst requests
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = NewRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithRateLimiter sets a rate limiter that may be shared with other clients
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
 // NewClient creates a new Notion client
 func NewClient(apiKey string, options ...ClientOption) *Client {
 	client := &Client{
@@ -64,57 +92,62 @@ func NewClient(apiKey string, options ...ClientOption) *Client {
 		option(client)
 	}
 
+	client.handler = client.buildHandler()
+
 	return client
 }
 
-// Error represents an API error
-type Error struct {
-	Object  string `json:"object"`
-	Status  int    `json:"status"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
+// makeRequest makes an HTTP request to the Notion API
+func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	req := &Request{
+		Method: method,
+		Path:   path,
+		Body:   body,
+		Header: http.Header{},
+	}
 
-func (e *Error) Error() string {
-	return fmt.Sprintf("notion: %s (status: %d, code: %s)", e.Message, e.Status, e.Code)
+	// Set headers
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Notion-Version", c.version)
+
+	resp, err := c.handler(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, decodeError(resp)
+	}
+
+	return resp, nil
 }
 
-// makeRequest makes an HTTP request to the Notion API
-func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+// do performs a single HTTP round trip, encoding a fresh request body each time
+func (c *Client) do(ctx context.Context, r *Request) (*http.Response, error) {
 	var reqBody io.Reader
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
+	if r.Body != nil {
+		jsonBody, err := json.Marshal(r.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	url := c.baseURL + path
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	url := c.baseURL + r.Path
+	req, err := http.NewRequestWithContext(ctx, r.Method, url, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-
-	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Notion-Version", c.version)
+	for key, values := range r.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		var apiErr Error
-		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
-			return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
-		}
-		return nil, &apiErr
-	}
-
 	return resp, nil
 }
 
@@ -0,0 +1,122 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Comment represents a Notion comment
+type Comment struct {
+	Object         string     `json:"object"`
+	ID             string     `json:"id"`
+	Parent         *Parent    `json:"parent"`
+	DiscussionID   string     `json:"discussion_id"`
+	CreatedTime    string     `json:"created_time"`
+	CreatedBy      *User      `json:"created_by"`
+	LastEditedTime string     `json:"last_edited_time"`
+	RichText       []RichText `json:"rich_text"`
+}
+
+// Discussion represents a thread of comments sharing a discussion ID
+type Discussion struct {
+	ID       string
+	Comments []Comment
+}
+
+// CommentsListResponse represents a list of comments
+type CommentsListResponse struct {
+	ListResponse
+	Results []Comment `json:"results"`
+}
+
+// CreateCommentRequest represents a request to create a comment. Exactly one
+// of Parent or DiscussionID must be set: Parent starts a new discussion on a
+// page, DiscussionID replies to an existing discussion.
+type CreateCommentRequest struct {
+	Parent       *Parent    `json:"parent,omitempty"`
+	DiscussionID string     `json:"discussion_id,omitempty"`
+	RichText     []RichText `json:"rich_text"`
+}
+
+// CreateComment creates a comment on a page or in an existing discussion
+func (c *Client) CreateComment(ctx context.Context, req *CreateCommentRequest) (*Comment, error) {
+	resp, err := c.makeRequest(ctx, "POST", "/comments", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := parseResponse(resp, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// ListComments retrieves the unresolved comments on a block or page
+func (c *Client) ListComments(ctx context.Context, blockID string, startCursor string, pageSize int) (*CommentsListResponse, error) {
+	query := url.Values{}
+	query.Set("block_id", blockID)
+	if startCursor != "" {
+		query.Set("start_cursor", startCursor)
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", "/comments?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var comments CommentsListResponse
+	if err := parseResponse(resp, &comments); err != nil {
+		return nil, fmt.Errorf("failed to parse comments response: %w", err)
+	}
+
+	return &comments, nil
+}
+
+// CommentsPaginator returns a paginator over the comments on a block or page
+func (c *Client) CommentsPaginator(blockID string, pageSize int) *Paginator[Comment] {
+	return NewPaginator(func(ctx context.Context, cursor string) ([]Comment, ListResponse, error) {
+		resp, err := c.ListComments(ctx, blockID, cursor, pageSize)
+		if err != nil {
+			return nil, ListResponse{}, err
+		}
+		return resp.Results, resp.ListResponse, nil
+	})
+}
+
+// ListAllComments retrieves all comments on a block or page, handling pagination automatically
+func (c *Client) ListAllComments(ctx context.Context, blockID string) ([]Comment, error) {
+	return c.CommentsPaginator(blockID, 100).All(ctx)
+}
+
+// ListDiscussions retrieves all comments on a block or page grouped into discussions
+func (c *Client) ListDiscussions(ctx context.Context, blockID string) ([]Discussion, error) {
+	comments, err := c.ListAllComments(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return GroupDiscussions(comments), nil
+}
+
+// GroupDiscussions groups comments by discussion ID, keeping discussions and
+// the comments within them in the order they first appear
+func GroupDiscussions(comments []Comment) []Discussion {
+	var discussions []Discussion
+	index := map[string]int{}
+	for _, comment := range comments {
+		i, ok := index[comment.DiscussionID]
+		if !ok {
+			i = len(discussions)
+			index[comment.DiscussionID] = i
+			discussions = append(discussions, Discussion{ID: comment.DiscussionID})
+		}
+		discussions[i].Comments = append(discussions[i].Comments, comment)
+	}
+	return discussions
+}
@@ -0,0 +1,65 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateComment(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/comments" {
+			t.Errorf("Expected POST /comments, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"object":"comment","id":"c1","discussion_id":"d1","rich_text":[{"type":"text","plain_text":"LGTM"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	comment, err := client.CreateComment(context.Background(), NewDiscussionComment("d1", NewText("LGTM")))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if comment.ID != "c1" || comment.DiscussionID != "d1" {
+		t.Errorf("Expected comment c1 in d1, got %+v", comment)
+	}
+	if got["discussion_id"] != "d1" {
+		t.Errorf("Expected discussion_id 'd1', got %v", got["discussion_id"])
+	}
+	if _, ok := got["parent"]; ok {
+		t.Error("Expected parent to be omitted when replying to a discussion")
+	}
+}
+
+func TestListAllCommentsAndDiscussions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("block_id") != "page-id" {
+			t.Errorf("Expected block_id 'page-id', got '%s'", r.URL.Query().Get("block_id"))
+		}
+		if r.URL.Query().Get("start_cursor") == "" {
+			w.Write([]byte(`{"object":"list","results":[{"id":"c1","discussion_id":"d1"},{"id":"c2","discussion_id":"d2"}],"has_more":true,"next_cursor":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"list","results":[{"id":"c3","discussion_id":"d1"}],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	discussions, err := client.ListDiscussions(context.Background(), "page-id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(discussions) != 2 {
+		t.Fatalf("Expected 2 discussions, got %d", len(discussions))
+	}
+	if discussions[0].ID != "d1" || len(discussions[0].Comments) != 2 || discussions[0].Comments[1].ID != "c3" {
+		t.Errorf("Expected d1 to hold c1 and c3, got %+v", discussions[0])
+	}
+	if discussions[1].ID != "d2" || len(discussions[1].Comments) != 1 {
+		t.Errorf("Expected d2 to hold c2, got %+v", discussions[1])
+	}
+}
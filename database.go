@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -52,6 +53,26 @@ type DatabaseProperty struct {
 	Status         *StatusProperty        `json:"status,omitempty"`
 }
 
+// MarshalJSON encodes the property, sending an empty configuration for Type
+// when none is set, since Notion requires one to create or retype a property
+func (p DatabaseProperty) MarshalJSON() ([]byte, error) {
+	type property DatabaseProperty
+	data, err := json.Marshal(property(p))
+	if err != nil || p.Type == "" {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields[p.Type]; ok {
+		return data, nil
+	}
+	fields[p.Type] = json.RawMessage("{}")
+	return json.Marshal(fields)
+}
+
 // NumberProperty represents a number property configuration
 type NumberProperty struct {
 	Format string `json:"format"`
@@ -148,6 +169,57 @@ type UpdateDatabaseRequest struct {
 	Cover       *Cover                      `json:"cover,omitempty"`
 	Properties  map[string]DatabaseProperty `json:"properties,omitempty"`
 	Archived    *bool                       `json:"archived,omitempty"`
+	// RemoveProperties lists the names or IDs of properties to remove, which
+	// are sent as null
+	RemoveProperties []string `json:"-"`
+}
+
+// MarshalJSON encodes the request, sending null for removed properties.
+// Empty id, name and type fields of properties are left out, since Notion
+// reads them as a change.
+func (r UpdateDatabaseRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateDatabaseRequest
+	data, err := json.Marshal(request(r))
+	if err != nil || (len(r.Properties) == 0 && len(r.RemoveProperties) == 0) {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	properties := map[string]json.RawMessage{}
+	for name, property := range r.Properties {
+		if properties[name], err = updatedProperty(property); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range r.RemoveProperties {
+		properties[name] = json.RawMessage("null")
+	}
+	if fields["properties"], err = json.Marshal(properties); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// updatedProperty encodes a property of an update request without its empty
+// id, name and type fields
+func updatedProperty(p DatabaseProperty) (json.RawMessage, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"id", "name", "type"} {
+		if string(fields[key]) == `""` {
+			delete(fields, key)
+		}
+	}
+	return json.Marshal(fields)
 }
 
 // QueryDatabaseRequest represents a request to query a database
@@ -158,25 +230,117 @@ type QueryDatabaseRequest struct {
 	PageSize    int     `json:"page_size,omitempty"`
 }
 
-// Filter represents a database filter
+// Filter represents a database filter. Property filters set Property and
+// the filter matching the property type; timestamp filters set Timestamp and
+// CreatedTime or LastEditedTime instead.
 type Filter struct {
-	Property    string             `json:"property,omitempty"`
-	Type        string             `json:"type,omitempty"`
-	RichText    *TextFilter        `json:"rich_text,omitempty"`
-	Number      *NumberFilter      `json:"number,omitempty"`
-	Checkbox    *CheckboxFilter    `json:"checkbox,omitempty"`
-	Select      *SelectFilter      `json:"select,omitempty"`
-	MultiSelect *MultiSelectFilter `json:"multi_select,omitempty"`
-	Date        *DateFilter        `json:"date,omitempty"`
-	People      *PeopleFilter      `json:"people,omitempty"`
-	Files       *FilesFilter       `json:"files,omitempty"`
-	Relation    *RelationFilter    `json:"relation,omitempty"`
-	Formula     *FormulaFilter     `json:"formula,omitempty"`
-	Or          []Filter           `json:"or,omitempty"`
-	And         []Filter           `json:"and,omitempty"`
-}
-
-// TextFilter represents a text filter
+	Property       string             `json:"property,omitempty"`
+	Type           string             `json:"type,omitempty"`
+	Title          *TextFilter        `json:"title,omitempty"`
+	RichText       *TextFilter        `json:"rich_text,omitempty"`
+	URL            *TextFilter        `json:"url,omitempty"`
+	Email          *TextFilter        `json:"email,omitempty"`
+	PhoneNumber    *TextFilter        `json:"phone_number,omitempty"`
+	Number         *NumberFilter      `json:"number,omitempty"`
+	Checkbox       *CheckboxFilter    `json:"checkbox,omitempty"`
+	Select         *SelectFilter      `json:"select,omitempty"`
+	MultiSelect    *MultiSelectFilter `json:"multi_select,omitempty"`
+	Status         *StatusFilter      `json:"status,omitempty"`
+	Date           *DateFilter        `json:"date,omitempty"`
+	People         *PeopleFilter      `json:"people,omitempty"`
+	Files          *FilesFilter       `json:"files,omitempty"`
+	Relation       *RelationFilter    `json:"relation,omitempty"`
+	Formula        *FormulaFilter     `json:"formula,omitempty"`
+	Rollup         *RollupFilter      `json:"rollup,omitempty"`
+	UniqueID       *UniqueIDFilter    `json:"unique_id,omitempty"`
+	Timestamp      string             `json:"timestamp,omitempty"`
+	CreatedTime    *DateFilter        `json:"created_time,omitempty"`
+	LastEditedTime *DateFilter        `json:"last_edited_time,omitempty"`
+	Or             []Filter           `json:"or,omitempty"`
+	And            []Filter           `json:"and,omitempty"`
+}
+
+// MarshalJSON encodes the filter. When Type is set, only the condition
+// matching Type is sent, so a stale condition for another type left on a
+// reused Filter does not make the request invalid.
+func (f Filter) MarshalJSON() ([]byte, error) {
+	type filter Filter
+	if f.Type == "" {
+		return json.Marshal(filter(f))
+	}
+
+	typed := Filter{
+		Property:  f.Property,
+		Type:      f.Type,
+		Timestamp: f.Timestamp,
+		And:       f.And,
+		Or:        f.Or,
+	}
+	switch f.Type {
+	case PropertyTypeTitle:
+		typed.Title = f.Title
+	case PropertyTypeRichText:
+		typed.RichText = f.RichText
+	case PropertyTypeURL:
+		typed.URL = f.URL
+	case PropertyTypeEmail:
+		typed.Email = f.Email
+	case PropertyTypePhoneNumber:
+		typed.PhoneNumber = f.PhoneNumber
+	case PropertyTypeNumber:
+		typed.Number = f.Number
+	case PropertyTypeCheckbox:
+		typed.Checkbox = f.Checkbox
+	case PropertyTypeSelect:
+		typed.Select = f.Select
+	case PropertyTypeMultiSelect:
+		typed.MultiSelect = f.MultiSelect
+	case PropertyTypeStatus:
+		typed.Status = f.Status
+	case PropertyTypeDate:
+		typed.Date = f.Date
+	case PropertyTypePeople:
+		typed.People = f.People
+	case PropertyTypeFiles:
+		typed.Files = f.Files
+	case PropertyTypeRelation:
+		typed.Relation = f.Relation
+	case PropertyTypeFormula:
+		typed.Formula = f.Formula
+	case PropertyTypeRollup:
+		typed.Rollup = f.Rollup
+	case PropertyTypeUniqueID:
+		typed.UniqueID = f.UniqueID
+	case PropertyTypeCreatedTime:
+		typed.CreatedTime = f.CreatedTime
+	case PropertyTypeLastEditedTime:
+		typed.LastEditedTime = f.LastEditedTime
+	default:
+		return json.Marshal(filter(f))
+	}
+	return json.Marshal(filter(typed))
+}
+
+// Timestamp filter types
+const (
+	TimestampCreatedTime    = "created_time"
+	TimestampLastEditedTime = "last_edited_time"
+)
+
+// Relative date values accepted by the Equals, Before, After, OnOrBefore and
+// OnOrAfter conditions of a DateFilter
+const (
+	DateToday           = "today"
+	DateTomorrow        = "tomorrow"
+	DateYesterday       = "yesterday"
+	DateOneWeekAgo      = "one_week_ago"
+	DateOneWeekFromNow  = "one_week_from_now"
+	DateOneMonthAgo     = "one_month_ago"
+	DateOneMonthFromNow = "one_month_from_now"
+)
+
+// TextFilter represents a text filter. Notion only accepts true for
+// is_empty and is_not_empty, so those conditions are sent only when set.
 type TextFilter struct {
 	Equals         string `json:"equals,omitempty"`
 	DoesNotEqual   string `json:"does_not_equal,omitempty"`
@@ -222,13 +386,23 @@ type MultiSelectFilter struct {
 	IsNotEmpty     bool   `json:"is_not_empty,omitempty"`
 }
 
-// DateFilter represents a date filter
+// StatusFilter represents a status filter
+type StatusFilter struct {
+	Equals       string `json:"equals,omitempty"`
+	DoesNotEqual string `json:"does_not_equal,omitempty"`
+	IsEmpty      bool   `json:"is_empty,omitempty"`
+	IsNotEmpty   bool   `json:"is_not_empty,omitempty"`
+}
+
+// DateFilter represents a date filter. Equals, Before, After, OnOrBefore
+// and OnOrAfter take an ISO 8601 date or one of the relative Date constants.
 type DateFilter struct {
 	Equals     string `json:"equals,omitempty"`
 	Before     string `json:"before,omitempty"`
 	After      string `json:"after,omitempty"`
 	OnOrBefore string `json:"on_or_before,omitempty"`
 	OnOrAfter  string `json:"on_or_after,omitempty"`
+	ThisWeek   bool   `json:"this_week,omitempty"`
 	PastWeek   bool   `json:"past_week,omitempty"`
 	PastMonth  bool   `json:"past_month,omitempty"`
 	PastYear   bool   `json:"past_year,omitempty"`
@@ -239,6 +413,76 @@ type DateFilter struct {
 	IsNotEmpty bool   `json:"is_not_empty,omitempty"`
 }
 
+// dateFilterJSON is the wire format of a DateFilter, where the relative
+// period conditions are empty objects rather than booleans
+type dateFilterJSON struct {
+	Equals     string    `json:"equals,omitempty"`
+	Before     string    `json:"before,omitempty"`
+	After      string    `json:"after,omitempty"`
+	OnOrBefore string    `json:"on_or_before,omitempty"`
+	OnOrAfter  string    `json:"on_or_after,omitempty"`
+	ThisWeek   *struct{} `json:"this_week,omitempty"`
+	PastWeek   *struct{} `json:"past_week,omitempty"`
+	PastMonth  *struct{} `json:"past_month,omitempty"`
+	PastYear   *struct{} `json:"past_year,omitempty"`
+	NextWeek   *struct{} `json:"next_week,omitempty"`
+	NextMonth  *struct{} `json:"next_month,omitempty"`
+	NextYear   *struct{} `json:"next_year,omitempty"`
+	IsEmpty    bool      `json:"is_empty,omitempty"`
+	IsNotEmpty bool      `json:"is_not_empty,omitempty"`
+}
+
+// MarshalJSON encodes the relative period conditions as empty objects
+func (f DateFilter) MarshalJSON() ([]byte, error) {
+	period := func(set bool) *struct{} {
+		if set {
+			return &struct{}{}
+		}
+		return nil
+	}
+	return json.Marshal(dateFilterJSON{
+		Equals:     f.Equals,
+		Before:     f.Before,
+		After:      f.After,
+		OnOrBefore: f.OnOrBefore,
+		OnOrAfter:  f.OnOrAfter,
+		ThisWeek:   period(f.ThisWeek),
+		PastWeek:   period(f.PastWeek),
+		PastMonth:  period(f.PastMonth),
+		PastYear:   period(f.PastYear),
+		NextWeek:   period(f.NextWeek),
+		NextMonth:  period(f.NextMonth),
+		NextYear:   period(f.NextYear),
+		IsEmpty:    f.IsEmpty,
+		IsNotEmpty: f.IsNotEmpty,
+	})
+}
+
+// UnmarshalJSON decodes a date filter in its wire format
+func (f *DateFilter) UnmarshalJSON(data []byte) error {
+	var raw dateFilterJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = DateFilter{
+		Equals:     raw.Equals,
+		Before:     raw.Before,
+		After:      raw.After,
+		OnOrBefore: raw.OnOrBefore,
+		OnOrAfter:  raw.OnOrAfter,
+		ThisWeek:   raw.ThisWeek != nil,
+		PastWeek:   raw.PastWeek != nil,
+		PastMonth:  raw.PastMonth != nil,
+		PastYear:   raw.PastYear != nil,
+		NextWeek:   raw.NextWeek != nil,
+		NextMonth:  raw.NextMonth != nil,
+		NextYear:   raw.NextYear != nil,
+		IsEmpty:    raw.IsEmpty,
+		IsNotEmpty: raw.IsNotEmpty,
+	}
+	return nil
+}
+
 // PeopleFilter represents a people filter
 type PeopleFilter struct {
 	Contains       string `json:"contains,omitempty"`
@@ -269,6 +513,27 @@ type FormulaFilter struct {
 	Date     *DateFilter     `json:"date,omitempty"`
 }
 
+// RollupFilter represents a rollup filter. Any, Every and None apply a
+// property filter without a property name to each value of an array rollup;
+// Number and Date filter number and date rollups.
+type RollupFilter struct {
+	Any    *Filter       `json:"any,omitempty"`
+	Every  *Filter       `json:"every,omitempty"`
+	None   *Filter       `json:"none,omitempty"`
+	Number *NumberFilter `json:"number,omitempty"`
+	Date   *DateFilter   `json:"date,omitempty"`
+}
+
+// UniqueIDFilter represents a unique ID filter on the numeric part of the ID
+type UniqueIDFilter struct {
+	Equals               *int `json:"equals,omitempty"`
+	DoesNotEqual         *int `json:"does_not_equal,omitempty"`
+	GreaterThan          *int `json:"greater_than,omitempty"`
+	LessThan             *int `json:"less_than,omitempty"`
+	GreaterThanOrEqualTo *int `json:"greater_than_or_equal_to,omitempty"`
+	LessThanOrEqualTo    *int `json:"less_than_or_equal_to,omitempty"`
+}
+
 // Sort represents a sort configuration
 type Sort struct {
 	Property  string `json:"property,omitempty"`
@@ -341,3 +606,24 @@ func (c *Client) QueryDatabase(ctx context.Context, databaseID string, req *Quer
 
 	return &pages, nil
 }
+
+// QueryDatabasePaginator returns a paginator over every page matching the query
+func (c *Client) QueryDatabasePaginator(databaseID string, req *QueryDatabaseRequest) *Paginator[Page] {
+	query := QueryDatabaseRequest{}
+	if req != nil {
+		query = *req
+	}
+	return NewPaginator(func(ctx context.Context, cursor string) ([]Page, ListResponse, error) {
+		query.StartCursor = cursor
+		resp, err := c.QueryDatabase(ctx, databaseID, &query)
+		if err != nil {
+			return nil, ListResponse{}, err
+		}
+		return resp.Results, resp.ListResponse, nil
+	})
+}
+
+// QueryDatabaseAll queries a database and returns every matching page, handling pagination automatically
+func (c *Client) QueryDatabaseAll(ctx context.Context, databaseID string, req *QueryDatabaseRequest) ([]Page, error) {
+	return c.QueryDatabasePaginator(databaseID, req).All(ctx)
+}
@@ -0,0 +1,132 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Error codes returned by the Notion API
+const (
+	ErrorCodeInvalidJSON                   = "invalid_json"
+	ErrorCodeInvalidRequestURL             = "invalid_request_url"
+	ErrorCodeInvalidRequest                = "invalid_request"
+	ErrorCodeInvalidGrant                  = "invalid_grant"
+	ErrorCodeValidation                    = "validation_error"
+	ErrorCodeMissingVersion                = "missing_version"
+	ErrorCodeUnauthorized                  = "unauthorized"
+	ErrorCodeRestrictedResource            = "restricted_resource"
+	ErrorCodeObjectNotFound                = "object_not_found"
+	ErrorCodeConflict                      = "conflict_error"
+	ErrorCodeRateLimited                   = "rate_limited"
+	ErrorCodeInternalServerError           = "internal_server_error"
+	ErrorCodeServiceUnavailable            = "service_unavailable"
+	ErrorCodeDatabaseConnectionUnavailable = "database_connection_unavailable"
+	ErrorCodeGatewayTimeout                = "gateway_timeout"
+
+	// ErrorCodeBadGateway is not sent by Notion. It is assigned to 502
+	// responses, which come from proxies and carry no JSON error body.
+	ErrorCodeBadGateway = "bad_gateway"
+)
+
+// Sentinel errors for use with errors.Is. An *Error matches a sentinel when
+// their codes are equal.
+var (
+	ErrInvalidJSON         = &Error{Code: ErrorCodeInvalidJSON}
+	ErrInvalidRequestURL   = &Error{Code: ErrorCodeInvalidRequestURL}
+	ErrInvalidRequest      = &Error{Code: ErrorCodeInvalidRequest}
+	ErrValidation          = &Error{Code: ErrorCodeValidation}
+	ErrMissingVersion      = &Error{Code: ErrorCodeMissingVersion}
+	ErrUnauthorized        = &Error{Code: ErrorCodeUnauthorized}
+	ErrRestrictedResource  = &Error{Code: ErrorCodeRestrictedResource}
+	ErrNotFound            = &Error{Code: ErrorCodeObjectNotFound}
+	ErrConflict            = &Error{Code: ErrorCodeConflict}
+	ErrRateLimited         = &Error{Code: ErrorCodeRateLimited}
+	ErrInternalServerError = &Error{Code: ErrorCodeInternalServerError}
+	ErrServiceUnavailable  = &Error{Code: ErrorCodeServiceUnavailable}
+	ErrGatewayTimeout      = &Error{Code: ErrorCodeGatewayTimeout}
+	ErrBadGateway          = &Error{Code: ErrorCodeBadGateway}
+)
+
+// Error represents an API error
+type Error struct {
+	Object    string `json:"object"`
+	Status    int    `json:"status"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+	// Body is the raw response body
+	Body []byte `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("notion: %s (status: %d, code: %s)", e.Message, e.Status, e.Code)
+}
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is(err, notion.ErrNotFound) works
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code != "" && t.Code == e.Code
+}
+
+// decodeError converts an error response into an *Error. Bodies that are not
+// a Notion error object still produce an *Error carrying the status, the raw
+// body and the request ID.
+func decodeError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read error response with status %d: %w", resp.StatusCode, err)
+	}
+
+	var apiErr Error
+	if json.Unmarshal(body, &apiErr) != nil || apiErr.Code == "" {
+		apiErr = Error{
+			Object:  "error",
+			Code:    codeForStatus(resp.StatusCode),
+			Message: fmt.Sprintf("request failed with status %d", resp.StatusCode),
+		}
+	}
+	if apiErr.Status == 0 {
+		apiErr.Status = resp.StatusCode
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	apiErr.Body = body
+
+	return &apiErr
+}
+
+// codeForStatus returns the error code Notion uses for an HTTP status
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeInvalidRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeRestrictedResource
+	case http.StatusNotFound:
+		return ErrorCodeObjectNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		return ErrorCodeRateLimited
+	case http.StatusInternalServerError:
+		return ErrorCodeInternalServerError
+	case http.StatusBadGateway:
+		return ErrorCodeBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrorCodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrorCodeGatewayTimeout
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object":"error","status":404,"code":"object_not_found","message":"Could not find page","request_id":"req-1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	_, err := client.GetPage(context.Background(), "page-id")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected errors.Is(err, ErrNotFound), got %v", err)
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("Expected error not to match ErrUnauthorized")
+	}
+	if !errors.Is(fmt.Errorf("wrapped: %w", err), ErrNotFound) {
+		t.Error("Expected wrapped error to match ErrNotFound")
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error, got %T", err)
+	}
+	if apiErr.RequestID != "req-1" {
+		t.Errorf("Expected request ID 'req-1', got '%s'", apiErr.RequestID)
+	}
+	if apiErr.Message != "Could not find page" {
+		t.Errorf("Expected message 'Could not find page', got '%s'", apiErr.Message)
+	}
+}
+
+func TestErrorNonJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-2")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`<html>Service Unavailable</html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	_, err := client.GetPage(context.Background(), "page-id")
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error, got %T (%v)", err, err)
+	}
+	if apiErr.Status != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", apiErr.Status)
+	}
+	if apiErr.RequestID != "req-2" {
+		t.Errorf("Expected request ID 'req-2', got '%s'", apiErr.RequestID)
+	}
+	if string(apiErr.Body) != "<html>Service Unavailable</html>" {
+		t.Errorf("Expected raw body to be kept, got '%s'", apiErr.Body)
+	}
+	if !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("Expected errors.Is(err, ErrServiceUnavailable), got %v", err)
+	}
+}
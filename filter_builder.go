@@ -0,0 +1,752 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxFilterNesting is the number of levels compound filters may be nested
+// inside a top-level compound filter
+const MaxFilterNesting = 2
+
+// FilterBuilder builds a Filter fluently:
+//
+//	filter, err := notion.Where("Priority").Number().GreaterThan(3).
+//		And(notion.Where("Done").Checkbox().IsFalse()).
+//		Build()
+type FilterBuilder struct {
+	filter Filter
+	err    error
+}
+
+// PropertyCondition selects the filter type for a property
+type PropertyCondition struct {
+	c condition
+}
+
+// Where starts a filter on a database property
+func Where(property string) PropertyCondition {
+	return PropertyCondition{condition{property: property}}
+}
+
+// WhereCreatedTime starts a filter on the page creation time
+func WhereCreatedTime() DateCondition {
+	return DateCondition{condition{timestamp: TimestampCreatedTime}}
+}
+
+// WhereLastEditedTime starts a filter on the page last edited time
+func WhereLastEditedTime() DateCondition {
+	return DateCondition{condition{timestamp: TimestampLastEditedTime}}
+}
+
+// And combines filters into a compound filter matching all of them
+func And(filters ...*FilterBuilder) *FilterBuilder {
+	return compound(filterAnd, filters)
+}
+
+// Or combines filters into a compound filter matching any of them
+func Or(filters ...*FilterBuilder) *FilterBuilder {
+	return compound(filterOr, filters)
+}
+
+// And combines the filter with others into a compound filter matching all of them
+func (b *FilterBuilder) And(others ...*FilterBuilder) *FilterBuilder {
+	if len(b.filter.And) > 0 {
+		return compound(filterAnd, append(b.children(), others...))
+	}
+	return compound(filterAnd, append([]*FilterBuilder{b}, others...))
+}
+
+// Or combines the filter with others into a compound filter matching any of them
+func (b *FilterBuilder) Or(others ...*FilterBuilder) *FilterBuilder {
+	if len(b.filter.Or) > 0 {
+		return compound(filterOr, append(b.children(), others...))
+	}
+	return compound(filterOr, append([]*FilterBuilder{b}, others...))
+}
+
+// Build returns the filter, or the first error found while building it. It
+// checks that compound filters are nested at most MaxFilterNesting levels.
+func (b *FilterBuilder) Build() (*Filter, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if depth := compoundDepth(&b.filter); depth > MaxFilterNesting+1 {
+		return nil, fmt.Errorf("notion: compound filters are nested %d levels deep, at most %d are allowed", depth-1, MaxFilterNesting)
+	}
+	filter := b.filter
+	return &filter, nil
+}
+
+type compoundType int
+
+const (
+	filterAnd compoundType = iota
+	filterOr
+)
+
+// compound builds an and/or filter from several builders
+func compound(kind compoundType, builders []*FilterBuilder) *FilterBuilder {
+	result := &FilterBuilder{}
+	filters := make([]Filter, 0, len(builders))
+	for _, builder := range builders {
+		if builder == nil {
+			result.err = errors.New("notion: nil filter in compound filter")
+			continue
+		}
+		if builder.err != nil && result.err == nil {
+			result.err = builder.err
+		}
+		filters = append(filters, builder.filter)
+	}
+	if len(filters) == 0 && result.err == nil {
+		result.err = errors.New("notion: empty compound filter")
+	}
+
+	if kind == filterAnd {
+		result.filter.And = filters
+	} else {
+		result.filter.Or = filters
+	}
+	return result
+}
+
+// children returns the operands of a compound filter as builders
+func (b *FilterBuilder) children() []*FilterBuilder {
+	filters := b.filter.And
+	if len(filters) == 0 {
+		filters = b.filter.Or
+	}
+	children := make([]*FilterBuilder, 0, len(filters))
+	for _, filter := range filters {
+		children = append(children, &FilterBuilder{filter: filter, err: b.err})
+	}
+	return children
+}
+
+// compoundDepth returns the number of nested compound filter levels
+func compoundDepth(f *Filter) int {
+	if len(f.And) == 0 && len(f.Or) == 0 {
+		return 0
+	}
+	depth := 0
+	for i := range f.And {
+		depth = max(depth, compoundDepth(&f.And[i]))
+	}
+	for i := range f.Or {
+		depth = max(depth, compoundDepth(&f.Or[i]))
+	}
+	return depth + 1
+}
+
+// condition describes what a typed condition applies to
+type condition struct {
+	property  string
+	timestamp string
+	formula   bool
+	rollup    string
+	textKey   string
+}
+
+// leaf creates a builder for a single filter condition. set populates a
+// property filter, which is then wrapped for formula, rollup and timestamp
+// conditions.
+func (c condition) leaf(set func(f *Filter)) *FilterBuilder {
+	var inner Filter
+	set(&inner)
+
+	b := &FilterBuilder{}
+	switch {
+	case c.timestamp != "":
+		b.filter = Filter{Timestamp: c.timestamp}
+		if c.timestamp == TimestampCreatedTime {
+			b.filter.CreatedTime = inner.Date
+		} else {
+			b.filter.LastEditedTime = inner.Date
+		}
+		return b
+	case c.formula:
+		b.filter = Filter{Formula: &FormulaFilter{
+			String:   inner.RichText,
+			Checkbox: inner.Checkbox,
+			Number:   inner.Number,
+			Date:     inner.Date,
+		}}
+	case c.rollup == "number":
+		b.filter = Filter{Rollup: &RollupFilter{Number: inner.Number}}
+	case c.rollup == "date":
+		b.filter = Filter{Rollup: &RollupFilter{Date: inner.Date}}
+	case c.rollup == "any":
+		b.filter = Filter{Rollup: &RollupFilter{Any: &inner}}
+	case c.rollup == "every":
+		b.filter = Filter{Rollup: &RollupFilter{Every: &inner}}
+	case c.rollup == "none":
+		b.filter = Filter{Rollup: &RollupFilter{None: &inner}}
+	default:
+		b.filter = inner
+	}
+
+	b.filter.Property = c.property
+	if c.property == "" {
+		b.err = errors.New("notion: filter property must not be empty")
+	}
+	return b
+}
+
+func (c condition) text(tf *TextFilter) *FilterBuilder {
+	return c.leaf(func(f *Filter) {
+		switch c.textKey {
+		case PropertyTypeTitle:
+			f.Title = tf
+		case PropertyTypeURL:
+			f.URL = tf
+		case PropertyTypeEmail:
+			f.Email = tf
+		case PropertyTypePhoneNumber:
+			f.PhoneNumber = tf
+		default:
+			f.RichText = tf
+		}
+	})
+}
+
+func (c condition) number(nf *NumberFilter) *FilterBuilder {
+	return c.leaf(func(f *Filter) { f.Number = nf })
+}
+
+func (c condition) checkbox(cf *CheckboxFilter) *FilterBuilder {
+	return c.leaf(func(f *Filter) { f.Checkbox = cf })
+}
+
+func (c condition) date(df *DateFilter) *FilterBuilder {
+	return c.leaf(func(f *Filter) { f.Date = df })
+}
+
+// text returns a text condition on the given filter key
+func (p PropertyCondition) text(key string) TextCondition {
+	c := p.c
+	c.textKey = key
+	return TextCondition{c}
+}
+
+// Title filters a title property
+func (p PropertyCondition) Title() TextCondition {
+	return p.text(PropertyTypeTitle)
+}
+
+// RichText filters a rich_text property
+func (p PropertyCondition) RichText() TextCondition {
+	return p.text(PropertyTypeRichText)
+}
+
+// URL filters a url property
+func (p PropertyCondition) URL() TextCondition {
+	return p.text(PropertyTypeURL)
+}
+
+// Email filters an email property
+func (p PropertyCondition) Email() TextCondition {
+	return p.text(PropertyTypeEmail)
+}
+
+// PhoneNumber filters a phone_number property
+func (p PropertyCondition) PhoneNumber() TextCondition {
+	return p.text(PropertyTypePhoneNumber)
+}
+
+// Number filters a number property
+func (p PropertyCondition) Number() NumberCondition {
+	return NumberCondition{p.c}
+}
+
+// Checkbox filters a checkbox property
+func (p PropertyCondition) Checkbox() CheckboxCondition {
+	return CheckboxCondition{p.c}
+}
+
+// Select filters a select property
+func (p PropertyCondition) Select() SelectCondition {
+	return SelectCondition{p.c}
+}
+
+// MultiSelect filters a multi-select property
+func (p PropertyCondition) MultiSelect() MultiSelectCondition {
+	return MultiSelectCondition{p.c}
+}
+
+// Date filters a date property
+func (p PropertyCondition) Date() DateCondition {
+	return DateCondition{p.c}
+}
+
+// People filters a people property
+func (p PropertyCondition) People() ListCondition {
+	return ListCondition{p.c, func(f *Filter, lf *PeopleFilter) { f.People = lf }}
+}
+
+// Relation filters a relation property
+func (p PropertyCondition) Relation() ListCondition {
+	return ListCondition{p.c, func(f *Filter, lf *PeopleFilter) {
+		f.Relation = &RelationFilter{
+			Contains:       lf.Contains,
+			DoesNotContain: lf.DoesNotContain,
+			IsEmpty:        lf.IsEmpty,
+			IsNotEmpty:     lf.IsNotEmpty,
+		}
+	}}
+}
+
+// Files filters a files property
+func (p PropertyCondition) Files() FilesCondition {
+	return FilesCondition{p.c}
+}
+
+// Formula filters a formula property by the type of its result
+func (p PropertyCondition) Formula() FormulaCondition {
+	return FormulaCondition{p.c.with(func(c *condition) { c.formula = true })}
+}
+
+// Status filters a status property
+func (p PropertyCondition) Status() StatusCondition {
+	return StatusCondition{p.c}
+}
+
+// UniqueID filters a unique_id property
+func (p PropertyCondition) UniqueID() UniqueIDCondition {
+	return UniqueIDCondition{p.c}
+}
+
+// Rollup filters a rollup property
+func (p PropertyCondition) Rollup() RollupCondition {
+	return RollupCondition{p.c}
+}
+
+// with returns a copy of c modified by fn
+func (c condition) with(fn func(c *condition)) condition {
+	fn(&c)
+	return c
+}
+
+// FormulaCondition selects the result type of a formula filter
+type FormulaCondition struct {
+	c condition
+}
+
+// String filters a formula with a string result
+func (f FormulaCondition) String() TextCondition {
+	return TextCondition{f.c.with(func(c *condition) { c.textKey = PropertyTypeRichText })}
+}
+
+// Number filters a formula with a number result
+func (f FormulaCondition) Number() NumberCondition { return NumberCondition{f.c} }
+
+// Checkbox filters a formula with a boolean result
+func (f FormulaCondition) Checkbox() CheckboxCondition { return CheckboxCondition{f.c} }
+
+// Date filters a formula with a date result
+func (f FormulaCondition) Date() DateCondition { return DateCondition{f.c} }
+
+// RollupCondition selects how a rollup filter applies to the rollup value
+type RollupCondition struct {
+	c condition
+}
+
+// Any matches rollups where any array value matches the condition
+func (r RollupCondition) Any() PropertyCondition {
+	return PropertyCondition{r.c.with(func(c *condition) { c.rollup = "any" })}
+}
+
+// Every matches rollups where every array value matches the condition
+func (r RollupCondition) Every() PropertyCondition {
+	return PropertyCondition{r.c.with(func(c *condition) { c.rollup = "every" })}
+}
+
+// None matches rollups where no array value matches the condition
+func (r RollupCondition) None() PropertyCondition {
+	return PropertyCondition{r.c.with(func(c *condition) { c.rollup = "none" })}
+}
+
+// Number filters a rollup with a number result
+func (r RollupCondition) Number() NumberCondition {
+	return NumberCondition{r.c.with(func(c *condition) { c.rollup = "number" })}
+}
+
+// Date filters a rollup with a date result
+func (r RollupCondition) Date() DateCondition {
+	return DateCondition{r.c.with(func(c *condition) { c.rollup = "date" })}
+}
+
+// TextCondition builds text filter conditions
+type TextCondition struct {
+	c condition
+}
+
+// Equals matches text equal to value
+func (t TextCondition) Equals(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{Equals: value})
+}
+
+// DoesNotEqual matches text not equal to value
+func (t TextCondition) DoesNotEqual(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{DoesNotEqual: value})
+}
+
+// Contains matches text containing value
+func (t TextCondition) Contains(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{Contains: value})
+}
+
+// DoesNotContain matches text not containing value
+func (t TextCondition) DoesNotContain(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{DoesNotContain: value})
+}
+
+// StartsWith matches text starting with value
+func (t TextCondition) StartsWith(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{StartsWith: value})
+}
+
+// EndsWith matches text ending with value
+func (t TextCondition) EndsWith(value string) *FilterBuilder {
+	return t.c.text(&TextFilter{EndsWith: value})
+}
+
+// IsEmpty matches empty text
+func (t TextCondition) IsEmpty() *FilterBuilder {
+	return t.c.text(&TextFilter{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty text
+func (t TextCondition) IsNotEmpty() *FilterBuilder {
+	return t.c.text(&TextFilter{IsNotEmpty: true})
+}
+
+// NumberCondition builds number filter conditions
+type NumberCondition struct {
+	c condition
+}
+
+// Equals matches numbers equal to value
+func (n NumberCondition) Equals(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{Equals: &value})
+}
+
+// DoesNotEqual matches numbers not equal to value
+func (n NumberCondition) DoesNotEqual(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{DoesNotEqual: &value})
+}
+
+// GreaterThan matches numbers greater than value
+func (n NumberCondition) GreaterThan(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{GreaterThan: &value})
+}
+
+// LessThan matches numbers less than value
+func (n NumberCondition) LessThan(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{LessThan: &value})
+}
+
+// GreaterThanOrEqualTo matches numbers greater than or equal to value
+func (n NumberCondition) GreaterThanOrEqualTo(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{GreaterThanOrEqualTo: &value})
+}
+
+// LessThanOrEqualTo matches numbers less than or equal to value
+func (n NumberCondition) LessThanOrEqualTo(value float64) *FilterBuilder {
+	return n.c.number(&NumberFilter{LessThanOrEqualTo: &value})
+}
+
+// IsEmpty matches empty numbers
+func (n NumberCondition) IsEmpty() *FilterBuilder {
+	return n.c.number(&NumberFilter{IsEmpty: true})
+}
+
+// IsNotEmpty matches non-empty numbers
+func (n NumberCondition) IsNotEmpty() *FilterBuilder {
+	return n.c.number(&NumberFilter{IsNotEmpty: true})
+}
+
+// CheckboxCondition builds checkbox filter conditions
+type CheckboxCondition struct {
+	c condition
+}
+
+// Equals matches checkboxes equal to value
+func (b CheckboxCondition) Equals(value bool) *FilterBuilder {
+	return b.c.checkbox(&CheckboxFilter{Equals: &value})
+}
+
+// DoesNotEqual matches checkboxes not equal to value
+func (b CheckboxCondition) DoesNotEqual(value bool) *FilterBuilder {
+	return b.c.checkbox(&CheckboxFilter{DoesNotEqual: &value})
+}
+
+// IsTrue matches checked checkboxes
+func (b CheckboxCondition) IsTrue() *FilterBuilder {
+	return b.Equals(true)
+}
+
+// IsFalse matches unchecked checkboxes
+func (b CheckboxCondition) IsFalse() *FilterBuilder {
+	return b.Equals(false)
+}
+
+// SelectCondition builds select filter conditions
+type SelectCondition struct {
+	c condition
+}
+
+// Equals matches the option named value
+func (s SelectCondition) Equals(value string) *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Select = &SelectFilter{Equals: value} })
+}
+
+// DoesNotEqual matches any option other than value
+func (s SelectCondition) DoesNotEqual(value string) *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Select = &SelectFilter{DoesNotEqual: value} })
+}
+
+// IsEmpty matches pages without an option
+func (s SelectCondition) IsEmpty() *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Select = &SelectFilter{IsEmpty: true} })
+}
+
+// IsNotEmpty matches pages with an option
+func (s SelectCondition) IsNotEmpty() *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Select = &SelectFilter{IsNotEmpty: true} })
+}
+
+// MultiSelectCondition builds multi-select filter conditions
+type MultiSelectCondition struct {
+	c condition
+}
+
+// Contains matches pages with the option named value
+func (m MultiSelectCondition) Contains(value string) *FilterBuilder {
+	return m.c.leaf(func(f *Filter) { f.MultiSelect = &MultiSelectFilter{Contains: value} })
+}
+
+// DoesNotContain matches pages without the option named value
+func (m MultiSelectCondition) DoesNotContain(value string) *FilterBuilder {
+	return m.c.leaf(func(f *Filter) { f.MultiSelect = &MultiSelectFilter{DoesNotContain: value} })
+}
+
+// IsEmpty matches pages without options
+func (m MultiSelectCondition) IsEmpty() *FilterBuilder {
+	return m.c.leaf(func(f *Filter) { f.MultiSelect = &MultiSelectFilter{IsEmpty: true} })
+}
+
+// IsNotEmpty matches pages with at least one option
+func (m MultiSelectCondition) IsNotEmpty() *FilterBuilder {
+	return m.c.leaf(func(f *Filter) { f.MultiSelect = &MultiSelectFilter{IsNotEmpty: true} })
+}
+
+// DateCondition builds date filter conditions
+type DateCondition struct {
+	c condition
+}
+
+// Equals matches dates equal to t
+func (d DateCondition) Equals(t time.Time) *FilterBuilder {
+	return d.c.date(&DateFilter{Equals: formatNotionTime(t)})
+}
+
+// Before matches dates before t
+func (d DateCondition) Before(t time.Time) *FilterBuilder {
+	return d.c.date(&DateFilter{Before: formatNotionTime(t)})
+}
+
+// After matches dates after t
+func (d DateCondition) After(t time.Time) *FilterBuilder {
+	return d.c.date(&DateFilter{After: formatNotionTime(t)})
+}
+
+// OnOrBefore matches dates on or before t
+func (d DateCondition) OnOrBefore(t time.Time) *FilterBuilder {
+	return d.c.date(&DateFilter{OnOrBefore: formatNotionTime(t)})
+}
+
+// OnOrAfter matches dates on or after t
+func (d DateCondition) OnOrAfter(t time.Time) *FilterBuilder {
+	return d.c.date(&DateFilter{OnOrAfter: formatNotionTime(t)})
+}
+
+// EqualsRelative matches dates equal to a relative date such as DateToday
+func (d DateCondition) EqualsRelative(value string) *FilterBuilder {
+	return d.c.date(&DateFilter{Equals: value})
+}
+
+// BeforeRelative matches dates before a relative date such as DateToday
+func (d DateCondition) BeforeRelative(value string) *FilterBuilder {
+	return d.c.date(&DateFilter{Before: value})
+}
+
+// AfterRelative matches dates after a relative date such as DateToday
+func (d DateCondition) AfterRelative(value string) *FilterBuilder {
+	return d.c.date(&DateFilter{After: value})
+}
+
+// OnOrBeforeRelative matches dates on or before a relative date such as DateToday
+func (d DateCondition) OnOrBeforeRelative(value string) *FilterBuilder {
+	return d.c.date(&DateFilter{OnOrBefore: value})
+}
+
+// OnOrAfterRelative matches dates on or after a relative date such as DateToday
+func (d DateCondition) OnOrAfterRelative(value string) *FilterBuilder {
+	return d.c.date(&DateFilter{OnOrAfter: value})
+}
+
+// ThisWeek matches dates within the current week
+func (d DateCondition) ThisWeek() *FilterBuilder {
+	return d.c.date(&DateFilter{ThisWeek: true})
+}
+
+// PastWeek matches dates within the past week
+func (d DateCondition) PastWeek() *FilterBuilder {
+	return d.c.date(&DateFilter{PastWeek: true})
+}
+
+// PastMonth matches dates within the past month
+func (d DateCondition) PastMonth() *FilterBuilder {
+	return d.c.date(&DateFilter{PastMonth: true})
+}
+
+// PastYear matches dates within the past year
+func (d DateCondition) PastYear() *FilterBuilder {
+	return d.c.date(&DateFilter{PastYear: true})
+}
+
+// NextWeek matches dates within the next week
+func (d DateCondition) NextWeek() *FilterBuilder {
+	return d.c.date(&DateFilter{NextWeek: true})
+}
+
+// NextMonth matches dates within the next month
+func (d DateCondition) NextMonth() *FilterBuilder {
+	return d.c.date(&DateFilter{NextMonth: true})
+}
+
+// NextYear matches dates within the next year
+func (d DateCondition) NextYear() *FilterBuilder {
+	return d.c.date(&DateFilter{NextYear: true})
+}
+
+// IsEmpty matches pages without a date
+func (d DateCondition) IsEmpty() *FilterBuilder {
+	return d.c.date(&DateFilter{IsEmpty: true})
+}
+
+// IsNotEmpty matches pages with a date
+func (d DateCondition) IsNotEmpty() *FilterBuilder {
+	return d.c.date(&DateFilter{IsNotEmpty: true})
+}
+
+// ListCondition builds people and relation filter conditions
+type ListCondition struct {
+	c   condition
+	set func(f *Filter, lf *PeopleFilter)
+}
+
+func (l ListCondition) list(lf *PeopleFilter) *FilterBuilder {
+	return l.c.leaf(func(f *Filter) { l.set(f, lf) })
+}
+
+// Contains matches pages referencing the user or page with the given ID
+func (l ListCondition) Contains(id string) *FilterBuilder {
+	return l.list(&PeopleFilter{Contains: id})
+}
+
+// DoesNotContain matches pages not referencing the user or page with the given ID
+func (l ListCondition) DoesNotContain(id string) *FilterBuilder {
+	return l.list(&PeopleFilter{DoesNotContain: id})
+}
+
+// IsEmpty matches pages without references
+func (l ListCondition) IsEmpty() *FilterBuilder {
+	return l.list(&PeopleFilter{IsEmpty: true})
+}
+
+// IsNotEmpty matches pages with at least one reference
+func (l ListCondition) IsNotEmpty() *FilterBuilder {
+	return l.list(&PeopleFilter{IsNotEmpty: true})
+}
+
+// FilesCondition builds files filter conditions
+type FilesCondition struct {
+	c condition
+}
+
+// IsEmpty matches pages without files
+func (fc FilesCondition) IsEmpty() *FilterBuilder {
+	return fc.c.leaf(func(f *Filter) { f.Files = &FilesFilter{IsEmpty: true} })
+}
+
+// IsNotEmpty matches pages with files
+func (fc FilesCondition) IsNotEmpty() *FilterBuilder {
+	return fc.c.leaf(func(f *Filter) { f.Files = &FilesFilter{IsNotEmpty: true} })
+}
+
+// StatusCondition builds status filter conditions
+type StatusCondition struct {
+	c condition
+}
+
+// Equals matches the status named value
+func (s StatusCondition) Equals(value string) *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Status = &StatusFilter{Equals: value} })
+}
+
+// DoesNotEqual matches any status other than value
+func (s StatusCondition) DoesNotEqual(value string) *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Status = &StatusFilter{DoesNotEqual: value} })
+}
+
+// IsEmpty matches pages without a status
+func (s StatusCondition) IsEmpty() *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Status = &StatusFilter{IsEmpty: true} })
+}
+
+// IsNotEmpty matches pages with a status
+func (s StatusCondition) IsNotEmpty() *FilterBuilder {
+	return s.c.leaf(func(f *Filter) { f.Status = &StatusFilter{IsNotEmpty: true} })
+}
+
+// UniqueIDCondition builds unique ID filter conditions on the numeric part of the ID
+type UniqueIDCondition struct {
+	c condition
+}
+
+func (u UniqueIDCondition) uniqueID(uf *UniqueIDFilter) *FilterBuilder {
+	return u.c.leaf(func(f *Filter) { f.UniqueID = uf })
+}
+
+// Equals matches IDs equal to value
+func (u UniqueIDCondition) Equals(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{Equals: &value})
+}
+
+// DoesNotEqual matches IDs not equal to value
+func (u UniqueIDCondition) DoesNotEqual(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{DoesNotEqual: &value})
+}
+
+// GreaterThan matches IDs greater than value
+func (u UniqueIDCondition) GreaterThan(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{GreaterThan: &value})
+}
+
+// LessThan matches IDs less than value
+func (u UniqueIDCondition) LessThan(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{LessThan: &value})
+}
+
+// GreaterThanOrEqualTo matches IDs greater than or equal to value
+func (u UniqueIDCondition) GreaterThanOrEqualTo(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{GreaterThanOrEqualTo: &value})
+}
+
+// LessThanOrEqualTo matches IDs less than or equal to value
+func (u UniqueIDCondition) LessThanOrEqualTo(value int) *FilterBuilder {
+	return u.uniqueID(&UniqueIDFilter{LessThanOrEqualTo: &value})
+}
@@ -0,0 +1,131 @@
+package notion
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	filter, err := Where("Priority").Number().GreaterThan(3).
+		And(Where("Done").Checkbox().IsFalse()).
+		And(Where("Tags").MultiSelect().Contains("go")).
+		Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, _ := json.Marshal(filter)
+	expected := `{"and":[` +
+		`{"property":"Priority","number":{"greater_than":3}},` +
+		`{"property":"Done","checkbox":{"equals":false}},` +
+		`{"property":"Tags","multi_select":{"contains":"go"}}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestFilterBuilderFormulaAndRelation(t *testing.T) {
+	filter, err := Or(
+		Where("Score").Formula().Number().LessThanOrEqualTo(10),
+		Where("Parent").Relation().Contains("page-id"),
+	).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(filter.Or) != 2 {
+		t.Fatalf("Expected 2 filters, got %d", len(filter.Or))
+	}
+	if *filter.Or[0].Formula.Number.LessThanOrEqualTo != 10 {
+		t.Errorf("Expected formula number filter, got %+v", filter.Or[0])
+	}
+	if filter.Or[1].Relation.Contains != "page-id" {
+		t.Errorf("Expected relation filter, got %+v", filter.Or[1])
+	}
+}
+
+func TestFilterBuilderNestingLimit(t *testing.T) {
+	leaf := func() *FilterBuilder { return Where("Done").Checkbox().IsTrue() }
+
+	_, err := And(leaf(), Or(leaf(), And(leaf(), leaf()))).Build()
+	if err != nil {
+		t.Errorf("Expected two nested levels to be allowed, got %v", err)
+	}
+
+	_, err = And(leaf(), Or(leaf(), And(leaf(), Or(leaf(), leaf())))).Build()
+	if err == nil || !strings.Contains(err.Error(), "nested 3 levels deep") {
+		t.Errorf("Expected nesting error, got %v", err)
+	}
+}
+
+func TestFilterBuilderEmptyProperty(t *testing.T) {
+	_, err := Where("").RichText().Contains("x").And(Where("Done").Checkbox().IsTrue()).Build()
+	if err == nil {
+		t.Error("Expected an error for an empty property name")
+	}
+}
+
+func TestFilterBuilderNewFilterTypes(t *testing.T) {
+	filter, err := And(
+		Where("Name").Title().StartsWith("RFC"),
+		Where("Stage").Status().Equals("Done"),
+		Where("Ticket").UniqueID().GreaterThan(100),
+		WhereLastEditedTime().ThisWeek(),
+		Where("Due").Date().OnOrBeforeRelative(DateToday),
+		Where("Tags").Rollup().Any().RichText().Contains("urgent"),
+		Where("Total").Rollup().Number().GreaterThan(5),
+		Where("Site").URL().IsNotEmpty(),
+	).Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	data, _ := json.Marshal(filter)
+	expected := `{"and":[` +
+		`{"property":"Name","title":{"starts_with":"RFC"}},` +
+		`{"property":"Stage","status":{"equals":"Done"}},` +
+		`{"property":"Ticket","unique_id":{"greater_than":100}},` +
+		`{"timestamp":"last_edited_time","last_edited_time":{"this_week":{}}},` +
+		`{"property":"Due","date":{"on_or_before":"today"}},` +
+		`{"property":"Tags","rollup":{"any":{"rich_text":{"contains":"urgent"}}}},` +
+		`{"property":"Total","rollup":{"number":{"greater_than":5}}},` +
+		`{"property":"Site","url":{"is_not_empty":true}}]}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestDateFilterJSON(t *testing.T) {
+	data, err := json.Marshal(DateFilter{PastWeek: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data) != `{"past_week":{}}` {
+		t.Errorf("Expected past_week as an empty object, got %s", data)
+	}
+
+	var filter DateFilter
+	if err := json.Unmarshal([]byte(`{"next_month":{},"after":"2024-01-01"}`), &filter); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !filter.NextMonth || filter.After != "2024-01-01" {
+		t.Errorf("Expected next_month and after to be decoded, got %+v", filter)
+	}
+}
+
+func TestFilterMarshalJSONWithType(t *testing.T) {
+	filter := Filter{
+		Property: "Done",
+		Type:     PropertyTypeCheckbox,
+		Checkbox: &CheckboxFilter{Equals: new(bool)},
+		RichText: &TextFilter{Contains: "stale"},
+	}
+	data, err := json.Marshal(filter)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"property":"Done","type":"checkbox","checkbox":{"equals":false}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
@@ -0,0 +1,824 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Evaluator evaluates database filters and sorts against pages locally,
+// following the semantics of the Notion API
+type Evaluator struct {
+	// Now returns the current time, used by relative date conditions such as
+	// DateToday or PastWeek. Days start at midnight in the location of the
+	// returned time. Nil means time.Now.
+	Now func() time.Time
+}
+
+// MatchFilter reports whether a page matches a database query filter,
+// evaluated at the current time
+func MatchFilter(page *Page, f *Filter) (bool, error) {
+	return (&Evaluator{}).MatchFilter(page, f)
+}
+
+// SortPages orders pages like a database query with the given sorts
+func SortPages(pages []Page, sorts []Sort) error {
+	return (&Evaluator{}).SortPages(pages, sorts)
+}
+
+func (e *Evaluator) now() time.Time {
+	if e.Now == nil {
+		return time.Now()
+	}
+	return e.Now()
+}
+
+// MatchFilter reports whether a page matches a database query filter. It
+// fails for filters that reference a property the page does not have or
+// whose condition does not fit the property type.
+func (e *Evaluator) MatchFilter(page *Page, f *Filter) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	return matchFilter(page, f, e.now())
+}
+
+func matchFilter(page *Page, f *Filter, now time.Time) (bool, error) {
+	if f.Type != "" {
+		// Only the condition matching Type applies, as when the filter is sent
+		typed, err := typedFilter(f)
+		if err != nil {
+			return false, err
+		}
+		f = typed
+	}
+
+	switch {
+	case len(f.And) > 0:
+		for i := range f.And {
+			match, err := matchFilter(page, &f.And[i], now)
+			if err != nil || !match {
+				return false, err
+			}
+		}
+		return true, nil
+	case len(f.Or) > 0:
+		for i := range f.Or {
+			match, err := matchFilter(page, &f.Or[i], now)
+			if err != nil || match {
+				return match, err
+			}
+		}
+		return false, nil
+	case f.Timestamp == TimestampCreatedTime:
+		if f.CreatedTime == nil {
+			return false, fmt.Errorf("notion: created_time timestamp filter has no condition")
+		}
+		return matchDate(&Date{Start: page.CreatedTime}, f.CreatedTime, now)
+	case f.Timestamp == TimestampLastEditedTime:
+		if f.LastEditedTime == nil {
+			return false, fmt.Errorf("notion: last_edited_time timestamp filter has no condition")
+		}
+		return matchDate(&Date{Start: page.LastEditedTime}, f.LastEditedTime, now)
+	case f.Timestamp != "":
+		return false, fmt.Errorf("notion: invalid timestamp filter %q", f.Timestamp)
+	}
+
+	if f.Property == "" {
+		return false, fmt.Errorf("notion: filter has no property")
+	}
+	value, ok := findPropertyValue(page, f.Property)
+	if !ok {
+		return false, fmt.Errorf("notion: could not find property with name or id: %s", f.Property)
+	}
+	return matchValue(value, f, now)
+}
+
+// typedFilter returns a copy of a filter holding only the condition matching
+// its Type
+func typedFilter(f *Filter) (*Filter, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("notion: invalid filter: %w", err)
+	}
+	var typed Filter
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, fmt.Errorf("notion: invalid filter: %w", err)
+	}
+	typed.Type = ""
+	return &typed, nil
+}
+
+// findPropertyValue looks up a page property by name or ID
+func findPropertyValue(page *Page, key string) (PageProperty, bool) {
+	if value, ok := page.Properties[key]; ok {
+		return value, true
+	}
+	for _, value := range page.Properties {
+		if value.ID == key {
+			return value, true
+		}
+	}
+	return PageProperty{}, false
+}
+
+// matchValue applies the condition of a property filter to a value
+func matchValue(value PageProperty, f *Filter, now time.Time) (bool, error) {
+	mismatch := func(filterType string) (bool, error) {
+		return false, fmt.Errorf("notion: %s property %s does not match %s filter", value.Type, f.Property, filterType)
+	}
+	is := func(types ...string) bool {
+		for _, t := range types {
+			if value.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case f.Title != nil:
+		if !is(PropertyTypeTitle) {
+			return mismatch(PropertyTypeTitle)
+		}
+		return matchText(PlainText(value.Title), f.Title), nil
+	case f.RichText != nil:
+		// Rich text conditions apply to every text property, like in Notion
+		if !is(PropertyTypeRichText, PropertyTypeTitle, PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber) {
+			return mismatch(PropertyTypeRichText)
+		}
+		return matchText(textValue(value), f.RichText), nil
+	case f.URL != nil:
+		if !is(PropertyTypeURL) {
+			return mismatch(PropertyTypeURL)
+		}
+		return matchText(value.URL, f.URL), nil
+	case f.Email != nil:
+		if !is(PropertyTypeEmail) {
+			return mismatch(PropertyTypeEmail)
+		}
+		return matchText(value.Email, f.Email), nil
+	case f.PhoneNumber != nil:
+		if !is(PropertyTypePhoneNumber) {
+			return mismatch(PropertyTypePhoneNumber)
+		}
+		return matchText(value.PhoneNumber, f.PhoneNumber), nil
+	case f.Number != nil:
+		if !is(PropertyTypeNumber) {
+			return mismatch(PropertyTypeNumber)
+		}
+		return matchNumber(value.Number, f.Number), nil
+	case f.Checkbox != nil:
+		if !is(PropertyTypeCheckbox) {
+			return mismatch(PropertyTypeCheckbox)
+		}
+		return matchCheckbox(value.Checkbox, f.Checkbox), nil
+	case f.Select != nil:
+		if !is(PropertyTypeSelect) {
+			return mismatch(PropertyTypeSelect)
+		}
+		name := ""
+		if value.Select != nil {
+			name = value.Select.Name
+		}
+		c := f.Select
+		return matchOption(name, c.Equals, c.DoesNotEqual, c.IsEmpty, c.IsNotEmpty), nil
+	case f.Status != nil:
+		if !is(PropertyTypeStatus) {
+			return mismatch(PropertyTypeStatus)
+		}
+		name := ""
+		if value.Status != nil {
+			name = value.Status.Name
+		}
+		c := f.Status
+		return matchOption(name, c.Equals, c.DoesNotEqual, c.IsEmpty, c.IsNotEmpty), nil
+	case f.MultiSelect != nil:
+		if !is(PropertyTypeMultiSelect) {
+			return mismatch(PropertyTypeMultiSelect)
+		}
+		names := make([]string, len(value.MultiSelect))
+		for i, option := range value.MultiSelect {
+			names[i] = option.Name
+		}
+		c := f.MultiSelect
+		return matchContains(names, c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty), nil
+	case f.Date != nil:
+		switch value.Type {
+		case PropertyTypeDate:
+			return matchDate(value.Date, f.Date, now)
+		case PropertyTypeCreatedTime:
+			return matchDate(&Date{Start: value.CreatedTime}, f.Date, now)
+		case PropertyTypeLastEditedTime:
+			return matchDate(&Date{Start: value.LastEditedTime}, f.Date, now)
+		}
+		return mismatch(PropertyTypeDate)
+	case f.CreatedTime != nil:
+		if !is(PropertyTypeCreatedTime) {
+			return mismatch(PropertyTypeCreatedTime)
+		}
+		return matchDate(&Date{Start: value.CreatedTime}, f.CreatedTime, now)
+	case f.LastEditedTime != nil:
+		if !is(PropertyTypeLastEditedTime) {
+			return mismatch(PropertyTypeLastEditedTime)
+		}
+		return matchDate(&Date{Start: value.LastEditedTime}, f.LastEditedTime, now)
+	case f.People != nil:
+		var ids []string
+		switch value.Type {
+		case PropertyTypePeople:
+			for _, user := range value.People {
+				ids = append(ids, user.ID)
+			}
+		case PropertyTypeCreatedBy:
+			if value.CreatedBy != nil {
+				ids = append(ids, value.CreatedBy.ID)
+			}
+		case PropertyTypeLastEditedBy:
+			if value.LastEditedBy != nil {
+				ids = append(ids, value.LastEditedBy.ID)
+			}
+		default:
+			return mismatch(PropertyTypePeople)
+		}
+		c := f.People
+		return matchContainsID(ids, c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty), nil
+	case f.Relation != nil:
+		if !is(PropertyTypeRelation) {
+			return mismatch(PropertyTypeRelation)
+		}
+		ids := make([]string, len(value.Relation))
+		for i, relation := range value.Relation {
+			ids[i] = relation.ID
+		}
+		c := f.Relation
+		return matchContainsID(ids, c.Contains, c.DoesNotContain, c.IsEmpty, c.IsNotEmpty), nil
+	case f.Files != nil:
+		if !is(PropertyTypeFiles) {
+			return mismatch(PropertyTypeFiles)
+		}
+		return matchEmpty(len(value.Files) == 0, f.Files.IsEmpty, f.Files.IsNotEmpty), nil
+	case f.Formula != nil:
+		if !is(PropertyTypeFormula) {
+			return mismatch(PropertyTypeFormula)
+		}
+		return matchFormula(value.Formula, f.Formula, now)
+	case f.Rollup != nil:
+		if !is(PropertyTypeRollup) {
+			return mismatch(PropertyTypeRollup)
+		}
+		return matchRollup(value.Rollup, f.Rollup, now)
+	case f.UniqueID != nil:
+		if !is(PropertyTypeUniqueID) {
+			return mismatch(PropertyTypeUniqueID)
+		}
+		return matchUniqueID(value.UniqueID, f.UniqueID), nil
+	}
+	return false, fmt.Errorf("notion: filter on property %s has no condition", f.Property)
+}
+
+// textValue returns the text of a text property
+func textValue(value PageProperty) string {
+	switch value.Type {
+	case PropertyTypeTitle:
+		return PlainText(value.Title)
+	case PropertyTypeRichText:
+		return PlainText(value.RichText)
+	case PropertyTypeURL:
+		return value.URL
+	case PropertyTypeEmail:
+		return value.Email
+	case PropertyTypePhoneNumber:
+		return value.PhoneNumber
+	}
+	return ""
+}
+
+func matchEmpty(empty, isEmpty, isNotEmpty bool) bool {
+	if isEmpty {
+		return empty
+	}
+	if isNotEmpty {
+		return !empty
+	}
+	return true
+}
+
+// matchText applies a text condition. Like Notion, comparisons ignore case.
+// Empty values only match is_empty, does_not_equal and does_not_contain.
+func matchText(s string, c *TextFilter) bool {
+	lower := strings.ToLower(s)
+	switch {
+	case c.IsEmpty || c.IsNotEmpty:
+		return matchEmpty(s == "", c.IsEmpty, c.IsNotEmpty)
+	case c.Equals != "":
+		return lower == strings.ToLower(c.Equals)
+	case c.DoesNotEqual != "":
+		return lower != strings.ToLower(c.DoesNotEqual)
+	case c.Contains != "":
+		return strings.Contains(lower, strings.ToLower(c.Contains))
+	case c.DoesNotContain != "":
+		return !strings.Contains(lower, strings.ToLower(c.DoesNotContain))
+	case c.StartsWith != "":
+		return strings.HasPrefix(lower, strings.ToLower(c.StartsWith))
+	case c.EndsWith != "":
+		return strings.HasSuffix(lower, strings.ToLower(c.EndsWith))
+	}
+	return true
+}
+
+// matchNumber applies a number condition. Empty values only match is_empty
+// and does_not_equal.
+func matchNumber(n *float64, c *NumberFilter) bool {
+	if c.IsEmpty || c.IsNotEmpty {
+		return matchEmpty(n == nil, c.IsEmpty, c.IsNotEmpty)
+	}
+	if c.DoesNotEqual != nil {
+		return n == nil || *n != *c.DoesNotEqual
+	}
+	if n == nil {
+		return false
+	}
+	switch {
+	case c.Equals != nil:
+		return *n == *c.Equals
+	case c.GreaterThan != nil:
+		return *n > *c.GreaterThan
+	case c.LessThan != nil:
+		return *n < *c.LessThan
+	case c.GreaterThanOrEqualTo != nil:
+		return *n >= *c.GreaterThanOrEqualTo
+	case c.LessThanOrEqualTo != nil:
+		return *n <= *c.LessThanOrEqualTo
+	}
+	return true
+}
+
+func matchUniqueID(id *UniqueID, c *UniqueIDFilter) bool {
+	if c.DoesNotEqual != nil {
+		return id == nil || id.Number != *c.DoesNotEqual
+	}
+	if id == nil {
+		return false
+	}
+	switch {
+	case c.Equals != nil:
+		return id.Number == *c.Equals
+	case c.GreaterThan != nil:
+		return id.Number > *c.GreaterThan
+	case c.LessThan != nil:
+		return id.Number < *c.LessThan
+	case c.GreaterThanOrEqualTo != nil:
+		return id.Number >= *c.GreaterThanOrEqualTo
+	case c.LessThanOrEqualTo != nil:
+		return id.Number <= *c.LessThanOrEqualTo
+	}
+	return true
+}
+
+func matchCheckbox(b bool, c *CheckboxFilter) bool {
+	switch {
+	case c.Equals != nil:
+		return b == *c.Equals
+	case c.DoesNotEqual != nil:
+		return b != *c.DoesNotEqual
+	}
+	return true
+}
+
+func matchOption(name, equals, doesNotEqual string, isEmpty, isNotEmpty bool) bool {
+	switch {
+	case isEmpty || isNotEmpty:
+		return matchEmpty(name == "", isEmpty, isNotEmpty)
+	case equals != "":
+		return name == equals
+	case doesNotEqual != "":
+		return name != doesNotEqual
+	}
+	return true
+}
+
+func matchContains(values []string, contains, doesNotContain string, isEmpty, isNotEmpty bool) bool {
+	has := func(s string) bool {
+		for _, v := range values {
+			if v == s {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case isEmpty || isNotEmpty:
+		return matchEmpty(len(values) == 0, isEmpty, isNotEmpty)
+	case contains != "":
+		return has(contains)
+	case doesNotContain != "":
+		return !has(doesNotContain)
+	}
+	return true
+}
+
+// matchContainsID is matchContains for IDs, which Notion accepts with or
+// without dashes
+func matchContainsID(ids []string, contains, doesNotContain string, isEmpty, isNotEmpty bool) bool {
+	for i, id := range ids {
+		ids[i] = compactID(id)
+	}
+	return matchContains(ids, compactID(contains), compactID(doesNotContain), isEmpty, isNotEmpty)
+}
+
+func compactID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// dateRange is the span of time covered by a date: a whole day for dates
+// without a time, an instant otherwise
+type dateRange struct {
+	start, end time.Time
+}
+
+func (r dateRange) overlaps(other dateRange) bool {
+	return r.start.Before(other.end) && other.start.Before(r.end)
+}
+
+// parseDateRange parses a date as returned by Notion. Date-only values cover
+// the whole day in loc.
+func parseDateRange(s, timeZone string, loc *time.Location) (dateRange, error) {
+	t, dateOnly, err := parseNotionDate(s, timeZone)
+	if err != nil {
+		return dateRange{}, err
+	}
+	if dateOnly {
+		return day(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc), 0), nil
+	}
+	return dateRange{t, t.Add(time.Nanosecond)}, nil
+}
+
+// day returns the range of the day containing t, shifted by days
+func day(t time.Time, days int) dateRange {
+	start := time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, t.Location())
+	return dateRange{start, time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())}
+}
+
+// conditionDate parses the value of a date condition, which may be a date
+// or one of the relative Date constants
+func conditionDate(value string, now time.Time) (dateRange, error) {
+	switch value {
+	case DateToday:
+		return day(now, 0), nil
+	case DateTomorrow:
+		return day(now, 1), nil
+	case DateYesterday:
+		return day(now, -1), nil
+	case DateOneWeekAgo:
+		return day(now, -7), nil
+	case DateOneWeekFromNow:
+		return day(now, 7), nil
+	case DateOneMonthAgo:
+		return day(now.AddDate(0, -1, 0), 0), nil
+	case DateOneMonthFromNow:
+		return day(now.AddDate(0, 1, 0), 0), nil
+	}
+	r, err := parseDateRange(value, "", now.Location())
+	if err != nil {
+		return r, fmt.Errorf("notion: invalid date condition: %w", err)
+	}
+	return r, nil
+}
+
+// matchDate applies a date condition to the start of a date. A date without
+// a time covers the whole day, so it equals every time within that day.
+func matchDate(date *Date, c *DateFilter, now time.Time) (bool, error) {
+	if c.IsEmpty || c.IsNotEmpty {
+		return matchEmpty(date == nil || date.Start == "", c.IsEmpty, c.IsNotEmpty), nil
+	}
+	if date == nil || date.Start == "" {
+		return false, nil
+	}
+	v, err := parseDateRange(date.Start, date.TimeZone, now.Location())
+	if err != nil {
+		return false, fmt.Errorf("notion: invalid date value: %w", err)
+	}
+
+	compare := func(value string, match func(dateRange) bool) (bool, error) {
+		r, err := conditionDate(value, now)
+		if err != nil {
+			return false, err
+		}
+		return match(r), nil
+	}
+	today := day(now, 0)
+	period := func(start, end time.Time) (bool, error) {
+		return v.overlaps(dateRange{start, end}), nil
+	}
+
+	switch {
+	case c.Equals != "":
+		return compare(c.Equals, v.overlaps)
+	case c.Before != "":
+		return compare(c.Before, func(r dateRange) bool { return !v.end.After(r.start) })
+	case c.After != "":
+		return compare(c.After, func(r dateRange) bool { return !v.start.Before(r.end) })
+	case c.OnOrBefore != "":
+		return compare(c.OnOrBefore, func(r dateRange) bool { return v.start.Before(r.end) })
+	case c.OnOrAfter != "":
+		return compare(c.OnOrAfter, func(r dateRange) bool { return v.end.After(r.start) })
+	case c.ThisWeek:
+		// Weeks start on Sunday, as in Notion's default settings
+		start := day(now, -int(now.Weekday())).start
+		return period(start, start.AddDate(0, 0, 7))
+	case c.PastWeek:
+		return period(today.start.AddDate(0, 0, -7), today.end)
+	case c.PastMonth:
+		return period(today.start.AddDate(0, -1, 0), today.end)
+	case c.PastYear:
+		return period(today.start.AddDate(-1, 0, 0), today.end)
+	case c.NextWeek:
+		return period(today.start, today.end.AddDate(0, 0, 7))
+	case c.NextMonth:
+		return period(today.start, today.end.AddDate(0, 1, 0))
+	case c.NextYear:
+		return period(today.start, today.end.AddDate(1, 0, 0))
+	}
+	return true, nil
+}
+
+// matchFormula applies the condition matching the formula result type
+func matchFormula(formula *Formula, c *FormulaFilter, now time.Time) (bool, error) {
+	if formula == nil {
+		formula = &Formula{}
+	}
+	mismatch := func(filterType string) (bool, error) {
+		return false, fmt.Errorf("notion: %s formula result does not match %s filter", formula.Type, filterType)
+	}
+	switch {
+	case c.String != nil:
+		if formula.Type != "string" {
+			return mismatch("string")
+		}
+		return matchText(formula.String, c.String), nil
+	case c.Checkbox != nil:
+		if formula.Type != "boolean" {
+			return mismatch("checkbox")
+		}
+		return matchCheckbox(formula.Boolean != nil && *formula.Boolean, c.Checkbox), nil
+	case c.Number != nil:
+		if formula.Type != "number" {
+			return mismatch("number")
+		}
+		return matchNumber(formula.Number, c.Number), nil
+	case c.Date != nil:
+		if formula.Type != "date" {
+			return mismatch("date")
+		}
+		return matchDate(formula.Date, c.Date, now)
+	}
+	return true, nil
+}
+
+// matchRollup applies a number or date condition to the rollup result, or a
+// property condition to the values of an array rollup
+func matchRollup(rollup *Rollup, c *RollupFilter, now time.Time) (bool, error) {
+	if rollup == nil {
+		rollup = &Rollup{}
+	}
+	switch {
+	case c.Number != nil:
+		return matchNumber(rollup.Number, c.Number), nil
+	case c.Date != nil:
+		return matchDate(rollup.Date, c.Date, now)
+	}
+
+	var inner *Filter
+	for _, f := range []*Filter{c.Any, c.Every, c.None} {
+		if f != nil {
+			inner = f
+		}
+	}
+	if inner == nil {
+		return true, nil
+	}
+	matches := 0
+	for _, item := range rollup.Array {
+		match, err := matchValue(rollupItem(item), inner, now)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			matches++
+		}
+	}
+	switch {
+	case c.Any != nil:
+		return matches > 0, nil
+	case c.Every != nil:
+		return matches == len(rollup.Array), nil
+	}
+	return matches == 0, nil
+}
+
+// rollupItem converts a rollup array value to the property value it holds
+func rollupItem(v RollupValue) PageProperty {
+	return PageProperty{
+		Type:           v.Type,
+		Title:          v.Title,
+		RichText:       v.RichText,
+		Number:         v.Number,
+		Select:         v.Select,
+		MultiSelect:    v.MultiSelect,
+		Date:           v.Date,
+		Formula:        v.Formula,
+		Relation:       v.Relation,
+		Rollup:         v.Rollup,
+		People:         v.People,
+		Files:          v.Files,
+		Checkbox:       v.Checkbox,
+		URL:            v.URL,
+		Email:          v.Email,
+		PhoneNumber:    v.PhoneNumber,
+		CreatedTime:    v.CreatedTime,
+		CreatedBy:      v.CreatedBy,
+		LastEditedTime: v.LastEditedTime,
+		LastEditedBy:   v.LastEditedBy,
+	}
+}
+
+// SortPages orders pages like a database query with the given sorts. Empty
+// values sort last in either direction and pages equal on every sort keep
+// their order. Select and status values sort by name, since the option order
+// of the database is not known.
+func (e *Evaluator) SortPages(pages []Page, sorts []Sort) error {
+	loc := e.now().Location()
+	for _, s := range sorts {
+		switch {
+		case s.Timestamp == TimestampCreatedTime, s.Timestamp == TimestampLastEditedTime:
+		case s.Timestamp != "":
+			return fmt.Errorf("notion: invalid sort timestamp %q", s.Timestamp)
+		case s.Property == "":
+			return fmt.Errorf("notion: sort has no property or timestamp")
+		default:
+			for i := range pages {
+				if _, ok := findPropertyValue(&pages[i], s.Property); !ok {
+					return fmt.Errorf("notion: could not find sort property with name or id: %s", s.Property)
+				}
+			}
+		}
+	}
+
+	key := func(page *Page, s Sort) sortKey {
+		switch s.Timestamp {
+		case TimestampCreatedTime:
+			return newSortKey(PageProperty{Type: PropertyTypeCreatedTime, CreatedTime: page.CreatedTime}, loc)
+		case TimestampLastEditedTime:
+			return newSortKey(PageProperty{Type: PropertyTypeLastEditedTime, LastEditedTime: page.LastEditedTime}, loc)
+		}
+		value, _ := findPropertyValue(page, s.Property)
+		return newSortKey(value, loc)
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		for _, s := range sorts {
+			a, b := key(&pages[i], s), key(&pages[j], s)
+			switch {
+			case a.empty && b.empty:
+				continue
+			case a.empty || b.empty:
+				return b.empty
+			}
+			c := a.compare(b)
+			if c == 0 {
+				continue
+			}
+			if s.Direction == SortDirectionDescending {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return nil
+}
+
+// sortKey is the value a property sorts by: text, a number or a time
+type sortKey struct {
+	empty bool
+	text  string
+	num   float64
+	time  time.Time
+}
+
+func (k sortKey) compare(other sortKey) int {
+	switch {
+	case !k.time.IsZero() || !other.time.IsZero():
+		return k.time.Compare(other.time)
+	case k.text != other.text:
+		return strings.Compare(k.text, other.text)
+	case k.num < other.num:
+		return -1
+	case k.num > other.num:
+		return 1
+	}
+	return 0
+}
+
+func newSortKey(value PageProperty, loc *time.Location) sortKey {
+	text := func(s string) sortKey {
+		return sortKey{text: strings.ToLower(s), empty: s == ""}
+	}
+	number := func(n *float64) sortKey {
+		if n == nil {
+			return sortKey{empty: true}
+		}
+		return sortKey{num: *n}
+	}
+	boolean := func(b bool) sortKey {
+		if b {
+			return sortKey{num: 1}
+		}
+		return sortKey{}
+	}
+	date := func(d *Date) sortKey {
+		if d == nil || d.Start == "" {
+			return sortKey{empty: true}
+		}
+		r, err := parseDateRange(d.Start, d.TimeZone, loc)
+		if err != nil {
+			return sortKey{empty: true}
+		}
+		return sortKey{time: r.start}
+	}
+
+	switch value.Type {
+	case PropertyTypeTitle, PropertyTypeRichText, PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber:
+		return text(textValue(value))
+	case PropertyTypeNumber:
+		return number(value.Number)
+	case PropertyTypeCheckbox:
+		return boolean(value.Checkbox)
+	case PropertyTypeSelect:
+		if value.Select == nil {
+			return sortKey{empty: true}
+		}
+		return text(value.Select.Name)
+	case PropertyTypeStatus:
+		if value.Status == nil {
+			return sortKey{empty: true}
+		}
+		return text(value.Status.Name)
+	case PropertyTypeMultiSelect:
+		names := make([]string, len(value.MultiSelect))
+		for i, option := range value.MultiSelect {
+			names[i] = option.Name
+		}
+		return text(strings.Join(names, ", "))
+	case PropertyTypePeople:
+		names := make([]string, len(value.People))
+		for i, user := range value.People {
+			names[i] = user.Name
+		}
+		key := text(strings.Join(names, ", "))
+		key.empty = len(value.People) == 0
+		return key
+	case PropertyTypeRelation, PropertyTypeFiles:
+		n := float64(len(value.Relation) + len(value.Files))
+		return sortKey{num: n, empty: n == 0}
+	case PropertyTypeDate:
+		return date(value.Date)
+	case PropertyTypeCreatedTime:
+		return date(&Date{Start: value.CreatedTime})
+	case PropertyTypeLastEditedTime:
+		return date(&Date{Start: value.LastEditedTime})
+	case PropertyTypeUniqueID:
+		if value.UniqueID == nil {
+			return sortKey{empty: true}
+		}
+		return sortKey{num: float64(value.UniqueID.Number)}
+	case PropertyTypeFormula:
+		if value.Formula == nil {
+			return sortKey{empty: true}
+		}
+		switch value.Formula.Type {
+		case "string":
+			return text(value.Formula.String)
+		case "number":
+			return number(value.Formula.Number)
+		case "boolean":
+			return boolean(value.Formula.Boolean != nil && *value.Formula.Boolean)
+		case "date":
+			return date(value.Formula.Date)
+		}
+	case PropertyTypeRollup:
+		if value.Rollup == nil {
+			return sortKey{empty: true}
+		}
+		switch value.Rollup.Type {
+		case "number":
+			return number(value.Rollup.Number)
+		case "date":
+			return date(value.Rollup.Date)
+		}
+	}
+	return sortKey{empty: true}
+}
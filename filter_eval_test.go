@@ -0,0 +1,173 @@
+package notion
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func float(n float64) *float64 {
+	return &n
+}
+
+func boolean(b bool) *bool {
+	return &b
+}
+
+func evalPage(name string, properties map[string]PageProperty) Page {
+	properties["Name"] = NewTitleProperty([]RichText{NewText(name)})
+	return Page{
+		ID:             "id-" + name,
+		CreatedTime:    "2024-05-01T10:00:00.000Z",
+		LastEditedTime: "2024-05-14T23:30:00.000Z",
+		Properties:     properties,
+	}
+}
+
+func TestMatchFilter(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC) // a Wednesday
+	e := &Evaluator{Now: func() time.Time { return now }}
+
+	page := evalPage("Launch Plan", map[string]PageProperty{
+		"Priority": NewNumberProperty(3),
+		"Empty":    {Type: PropertyTypeNumber},
+		"Done":     NewCheckboxProperty(false),
+		"Stage":    {Type: PropertyTypeStatus, Status: &StatusOption{Name: "In progress"}},
+		"Tags":     NewMultiSelectProperty([]SelectOption{{Name: "go"}, {Name: "api"}}),
+		"Due":      NewDateProperty(Date{Start: "2024-05-15"}),
+		"Meeting":  NewDateProperty(Date{Start: "2024-05-16T09:00:00", TimeZone: "America/New_York"}),
+		"Owner":    {Type: PropertyTypePeople, People: []User{{ID: "a1b2c3d4-0000-0000-0000-000000000000"}}},
+		"Blocks":   {Type: PropertyTypeRelation, Relation: []Relation{}},
+		"Score":    {Type: PropertyTypeFormula, Formula: &Formula{Type: "number", Number: float(7)}},
+		"Ready":    {Type: PropertyTypeFormula, Formula: &Formula{Type: "boolean", Boolean: boolean(true)}},
+		"Key":      {Type: PropertyTypeUniqueID, UniqueID: &UniqueID{Prefix: "TASK", Number: 12}},
+		"Estimates": {Type: PropertyTypeRollup, Rollup: &Rollup{Type: "array", Array: []RollupValue{
+			{Type: PropertyTypeNumber, Number: float(2)},
+			{Type: PropertyTypeNumber, Number: float(5)},
+		}}},
+	})
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{"title contains ignores case", Filter{Property: "Name", Title: &TextFilter{Contains: "plan"}}, true},
+		{"rich text condition on title", Filter{Property: "Name", RichText: &TextFilter{StartsWith: "launch"}}, true},
+		{"title does not equal", Filter{Property: "Name", Title: &TextFilter{DoesNotEqual: "Launch plan"}}, false},
+		{"number greater than", Filter{Property: "Priority", Number: &NumberFilter{GreaterThan: float(2)}}, true},
+		{"number is empty", Filter{Property: "Empty", Number: &NumberFilter{IsEmpty: true}}, true},
+		{"empty number does not equal", Filter{Property: "Empty", Number: &NumberFilter{DoesNotEqual: float(1)}}, true},
+		{"empty number less than", Filter{Property: "Empty", Number: &NumberFilter{LessThan: float(1)}}, false},
+		{"checkbox", Filter{Property: "Done", Checkbox: &CheckboxFilter{Equals: boolean(false)}}, true},
+		{"status", Filter{Property: "Stage", Status: &StatusFilter{Equals: "Done"}}, false},
+		{"multi-select contains", Filter{Property: "Tags", MultiSelect: &MultiSelectFilter{Contains: "api"}}, true},
+		{"date equals today", Filter{Property: "Due", Date: &DateFilter{Equals: DateToday}}, true},
+		{"date before tomorrow", Filter{Property: "Due", Date: &DateFilter{Before: DateTomorrow}}, true},
+		{"date after today", Filter{Property: "Due", Date: &DateFilter{After: "2024-05-15"}}, false},
+		{"date on or after today", Filter{Property: "Due", Date: &DateFilter{OnOrAfter: "2024-05-15T18:00:00Z"}}, true},
+		{"date time in a time zone", Filter{Property: "Meeting", Date: &DateFilter{Equals: "2024-05-16T13:00:00Z"}}, true},
+		{"this week", Filter{Property: "Meeting", Date: &DateFilter{ThisWeek: true}}, true},
+		{"past week", Filter{Property: "Meeting", Date: &DateFilter{PastWeek: true}}, false},
+		{"next week", Filter{Property: "Meeting", Date: &DateFilter{NextWeek: true}}, true},
+		{"people contains without dashes", Filter{Property: "Owner", People: &PeopleFilter{Contains: "a1b2c3d4000000000000000000000000"}}, true},
+		{"relation is empty", Filter{Property: "Blocks", Relation: &RelationFilter{IsEmpty: true}}, true},
+		{"formula number", Filter{Property: "Score", Formula: &FormulaFilter{Number: &NumberFilter{LessThanOrEqualTo: float(7)}}}, true},
+		{"formula checkbox", Filter{Property: "Ready", Formula: &FormulaFilter{Checkbox: &CheckboxFilter{Equals: boolean(true)}}}, true},
+		{"unique id", Filter{Property: "Key", UniqueID: &UniqueIDFilter{GreaterThan: intPtr(10)}}, true},
+		{"rollup any", Filter{Property: "Estimates", Rollup: &RollupFilter{Any: &Filter{Number: &NumberFilter{GreaterThan: float(4)}}}}, true},
+		{"rollup every", Filter{Property: "Estimates", Rollup: &RollupFilter{Every: &Filter{Number: &NumberFilter{GreaterThan: float(4)}}}}, false},
+		{"rollup none", Filter{Property: "Estimates", Rollup: &RollupFilter{None: &Filter{Number: &NumberFilter{Equals: float(9)}}}}, true},
+		{"created time timestamp", Filter{Timestamp: TimestampCreatedTime, CreatedTime: &DateFilter{PastMonth: true}}, true},
+		{"last edited yesterday", Filter{Timestamp: TimestampLastEditedTime, LastEditedTime: &DateFilter{Equals: DateYesterday}}, true},
+		{"type selects the condition", Filter{Property: "Priority", Type: PropertyTypeNumber, Number: &NumberFilter{Equals: float(3)}, Checkbox: &CheckboxFilter{}}, true},
+		{"and", Filter{And: []Filter{
+			{Property: "Done", Checkbox: &CheckboxFilter{Equals: boolean(false)}},
+			{Property: "Priority", Number: &NumberFilter{Equals: float(4)}},
+		}}, false},
+		{"nested or", Filter{Or: []Filter{
+			{Property: "Priority", Number: &NumberFilter{Equals: float(4)}},
+			{And: []Filter{
+				{Property: "Tags", MultiSelect: &MultiSelectFilter{Contains: "go"}},
+				{Property: "Stage", Status: &StatusFilter{IsNotEmpty: true}},
+			}},
+		}}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := e.MatchFilter(&page, &tt.filter)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", tt.name, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestMatchFilterErrors(t *testing.T) {
+	page := evalPage("Task", map[string]PageProperty{"Priority": NewNumberProperty(1)})
+
+	tests := map[string]Filter{
+		"could not find property": {Property: "Missing", Number: &NumberFilter{Equals: float(1)}},
+		"does not match":          {Property: "Priority", Checkbox: &CheckboxFilter{Equals: boolean(true)}},
+		"has no condition":        {Property: "Priority"},
+		"invalid date":            {Timestamp: TimestampCreatedTime, CreatedTime: &DateFilter{Before: "soon"}},
+	}
+	for message, filter := range tests {
+		_, err := MatchFilter(&page, &filter)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
+func TestSortPages(t *testing.T) {
+	pages := []Page{
+		evalPage("b", map[string]PageProperty{"Priority": NewNumberProperty(1), "Due": {Type: PropertyTypeDate}}),
+		evalPage("A", map[string]PageProperty{"Priority": NewNumberProperty(2), "Due": NewDateProperty(Date{Start: "2024-05-02"})}),
+		evalPage("c", map[string]PageProperty{"Priority": {Type: PropertyTypeNumber}, "Due": NewDateProperty(Date{Start: "2024-05-01T12:00:00Z"})}),
+		evalPage("d", map[string]PageProperty{"Priority": NewNumberProperty(2), "Due": NewDateProperty(Date{Start: "2024-05-03"})}),
+	}
+	names := func() string {
+		var s []string
+		for _, page := range pages {
+			s = append(s, PlainText(page.Properties["Name"].Title))
+		}
+		return strings.Join(s, " ")
+	}
+
+	if err := SortPages(pages, []Sort{{Property: "Name", Direction: SortDirectionAscending}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := names(); got != "A b c d" {
+		t.Errorf("Expected titles to sort ignoring case, got %s", got)
+	}
+
+	err := SortPages(pages, []Sort{
+		{Property: "Priority", Direction: SortDirectionDescending},
+		{Property: "Due", Direction: SortDirectionDescending},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := names(); got != "d A b c" {
+		t.Errorf("Expected descending priority, then due date, with empty values last, got %s", got)
+	}
+
+	if err := SortPages(pages, []Sort{{Property: "Due", Direction: SortDirectionAscending}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := names(); got != "c A d b" {
+		t.Errorf("Expected ascending dates with empty values last, got %s", got)
+	}
+
+	if err := SortPages(pages, []Sort{{Property: "Missing"}}); err == nil {
+		t.Error("Expected an error for an unknown sort property")
+	}
+}
@@ -1,5 +1,10 @@
 package notion
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Helper functions for creating common rich text and property values
 
 // NewText creates a new RichText with plain text
@@ -40,6 +45,68 @@ func NewAnnotatedText(content string, annotations *Annotations) RichText {
 	}
 }
 
+// NewUserMention creates a new RichText mentioning a user
+func NewUserMention(userID string) RichText {
+	return RichText{
+		Type: "mention",
+		Mention: &Mention{
+			Type: "user",
+			User: &User{
+				Object: ObjectTypeUser,
+				ID:     userID,
+			},
+		},
+	}
+}
+
+// NewPageMention creates a new RichText mentioning a page
+func NewPageMention(pageID string) RichText {
+	return RichText{
+		Type: "mention",
+		Mention: &Mention{
+			Type: "page",
+			Page: &Object{
+				ID: pageID,
+			},
+		},
+	}
+}
+
+// NewDateMention creates a new RichText mentioning a date
+func NewDateMention(date Date) RichText {
+	return RichText{
+		Type: "mention",
+		Mention: &Mention{
+			Type: "date",
+			Date: &date,
+		},
+	}
+}
+
+// NewEquationText creates a new inline equation RichText
+func NewEquationText(expression string) RichText {
+	return RichText{
+		Type: "equation",
+		Equation: &Equation{
+			Expression: expression,
+		},
+		PlainText: expression,
+	}
+}
+
+// PlainText concatenates the plain text of rich text elements
+func PlainText(richText []RichText) string {
+	var text strings.Builder
+	for _, rt := range richText {
+		if rt.PlainText != "" {
+			text.WriteString(rt.PlainText)
+		} else if rt.Text != nil {
+			text.WriteString(rt.Text.Content)
+		}
+	}
+	return text.String()
+}
+
 // NewParagraphBlock creates a new paragraph block
 func NewParagraphBlock(richText []RichText) *Block {
 	return &Block{
@@ -174,6 +241,192 @@ func NewTableRowBlock(cells [][]RichText) *Block {
 	}
 }
 
+// NewToggleBlock creates a new toggle block
+func NewToggleBlock(richText []RichText) *Block {
+	return &Block{
+		Type: "toggle",
+		Toggle: &ToggleBlock{
+			RichText: richText,
+		},
+	}
+}
+
+// NewEquationBlock creates a new equation block from a KaTeX expression
+func NewEquationBlock(expression string) *Block {
+	return &Block{
+		Type: "equation",
+		Equation: &EquationBlock{
+			Expression: expression,
+		},
+	}
+}
+
+// NewBookmarkBlock creates a new bookmark block
+func NewBookmarkBlock(url string) *Block {
+	return &Block{
+		Type: "bookmark",
+		Bookmark: &BookmarkBlock{
+			URL: url,
+		},
+	}
+}
+
+// NewEmbedBlock creates a new embed block
+func NewEmbedBlock(url string) *Block {
+	return &Block{
+		Type: "embed",
+		Embed: &EmbedBlock{
+			URL: url,
+		},
+	}
+}
+
+// NewImageBlock creates a new image block from an external URL
+func NewImageBlock(url string) *Block {
+	return &Block{
+		Type:  "image",
+		Image: newExternalFileBlock(url),
+	}
+}
+
+// NewVideoBlock creates a new video block from an external URL
+func NewVideoBlock(url string) *Block {
+	return &Block{
+		Type:  "video",
+		Video: newExternalFileBlock(url),
+	}
+}
+
+// NewPDFBlock creates a new PDF block from an external URL
+func NewPDFBlock(url string) *Block {
+	return &Block{
+		Type: "pdf",
+		PDF:  newExternalFileBlock(url),
+	}
+}
+
+// NewAudioBlock creates a new audio block from an external URL
+func NewAudioBlock(url string) *Block {
+	return &Block{
+		Type:  "audio",
+		Audio: newExternalFileBlock(url),
+	}
+}
+
+// NewFileBlock creates a new file block from an external URL
+func NewFileBlock(url string) *Block {
+	return &Block{
+		Type: "file",
+		File: newExternalFileBlock(url),
+	}
+}
+
+func newExternalFileBlock(url string) *FileBlock {
+	return &FileBlock{
+		Type: "external",
+		External: &File{
+			URL: url,
+		},
+	}
+}
+
+// NewLinkToPageBlock creates a new block linking to a page
+func NewLinkToPageBlock(pageID string) *Block {
+	return &Block{
+		Type: "link_to_page",
+		LinkToPage: &LinkToPageBlock{
+			Type:   "page_id",
+			PageID: pageID,
+		},
+	}
+}
+
+// NewLinkToDatabaseBlock creates a new block linking to a database
+func NewLinkToDatabaseBlock(databaseID string) *Block {
+	return &Block{
+		Type: "link_to_page",
+		LinkToPage: &LinkToPageBlock{
+			Type:       "database_id",
+			DatabaseID: databaseID,
+		},
+	}
+}
+
+// NewTableOfContentsBlock creates a new table of contents block
+func NewTableOfContentsBlock() *Block {
+	return &Block{
+		Type:            "table_of_contents",
+		TableOfContents: &TableOfContentsBlock{},
+	}
+}
+
+// NewBreadcrumbBlock creates a new breadcrumb block
+func NewBreadcrumbBlock() *Block {
+	return &Block{
+		Type:       "breadcrumb",
+		Breadcrumb: map[string]interface{}{},
+	}
+}
+
+// NewColumnBlock creates a new column holding the given blocks
+func NewColumnBlock(children ...*Block) *Block {
+	return (&Block{
+		Type:   "column",
+		Column: &ColumnBlock{},
+	}).WithChildren(children...)
+}
+
+// NewColumnListBlock creates a new column list from column blocks. Notion
+// requires at least two columns, each with at least one child.
+func NewColumnListBlock(columns ...*Block) (*Block, error) {
+	if len(columns) < 2 {
+		return nil, fmt.Errorf("column list needs at least 2 columns, got %d", len(columns))
+	}
+	for i, column := range columns {
+		if column == nil || column.Type != BlockTypeColumn {
+			return nil, fmt.Errorf("column list child %d is not a column", i)
+		}
+		if len(column.Children()) == 0 {
+			return nil, fmt.Errorf("column %d has no children", i)
+		}
+	}
+	return (&Block{
+		Type:       "column_list",
+		ColumnList: &ColumnListBlock{},
+	}).WithChildren(columns...), nil
+}
+
+// NewSyncedBlock creates a new original synced block holding the given blocks
+func NewSyncedBlock(children ...*Block) *Block {
+	return (&Block{
+		Type:   "synced_block",
+		Synced: &SyncedBlock{},
+	}).WithChildren(children...)
+}
+
+// NewSyncedBlockReference creates a new synced block duplicating an original synced block
+func NewSyncedBlockReference(blockID string) *Block {
+	return &Block{
+		Type: "synced_block",
+		Synced: &SyncedBlock{
+			SyncedFrom: &SyncedFrom{
+				Type:    "block_id",
+				BlockID: blockID,
+			},
+		},
+	}
+}
+
+// NewTemplateBlock creates a new template button block
+func NewTemplateBlock(richText []RichText) *Block {
+	return &Block{
+		Type: "template",
+		Template: &TemplateBlock{
+			RichText: richText,
+		},
+	}
+}
+
 // NewTitleProperty creates a new title property
 func NewTitleProperty(title []RichText) PageProperty {
 	return PageProperty{
@@ -222,6 +475,23 @@ func NewDateProperty(date Date) PageProperty {
 	}
 }
 
+// NewFilesProperty creates a new files property
+func NewFilesProperty(files []PropertyFile) PageProperty {
+	return PageProperty{
+		Type:  "files",
+		Files: files,
+	}
+}
+
+// NewExternalFile creates a file linked by URL for a files property
+func NewExternalFile(name, url string) PropertyFile {
+	return PropertyFile{
+		Name:     name,
+		Type:     "external",
+		External: &File{URL: url},
+	}
+}
+
 // NewCheckboxProperty creates a new checkbox property
 func NewCheckboxProperty(checked bool) PageProperty {
 	return PageProperty{
@@ -270,6 +540,16 @@ func NewRelationProperty(relations []Relation) PageProperty {
 	}
 }
 
+// NewEmptyProperty creates a property of the given type without a value.
+// Sent in an UpdatePageRequest it clears the property: select, status,
+// number, date, url, email and phone_number are sent as null, and list
+// types as an empty list.
+func NewEmptyProperty(propertyType string) PageProperty {
+	return PageProperty{
+		Type: propertyType,
+	}
+}
+
 // NewPageParent creates a new page parent
 func NewPageParent(pageID string) *Parent {
 	return &Parent{
@@ -294,6 +574,22 @@ func NewWorkspaceParent() *Parent {
 	}
 }
 
+// NewPageComment creates a request for a comment starting a new discussion on a page
+func NewPageComment(pageID string, richText ...RichText) *CreateCommentRequest {
+	return &CreateCommentRequest{
+		Parent:   NewPageParent(pageID),
+		RichText: richText,
+	}
+}
+
+// NewDiscussionComment creates a request for a reply in an existing discussion
+func NewDiscussionComment(discussionID string, richText ...RichText) *CreateCommentRequest {
+	return &CreateCommentRequest{
+		DiscussionID: discussionID,
+		RichText:     richText,
+	}
+}
+
 // NewEmojiIcon creates a new emoji icon
 func NewEmojiIcon(emoji string) *Icon {
 	return &Icon{
@@ -344,6 +640,7 @@ const (
 	PropertyTypeLastEditedTime = "last_edited_time"
 	PropertyTypeLastEditedBy   = "last_edited_by"
 	PropertyTypeStatus         = "status"
+	PropertyTypeUniqueID       = "unique_id"
 )
 
 // Block type constants
@@ -1,6 +1,7 @@
 package notion
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -288,3 +289,174 @@ func TestGenerateID(t *testing.T) {
 		t.Error("Expected non-empty ID")
 	}
 }
+
+func TestNewMentions(t *testing.T) {
+	user := NewUserMention("user-id")
+	if user.Type != "mention" || user.Mention.Type != "user" || user.Mention.User.ID != "user-id" {
+		t.Errorf("Expected user mention of 'user-id', got %+v", user)
+	}
+
+	page := NewPageMention("page-id")
+	if page.Mention.Type != "page" || page.Mention.Page.ID != "page-id" {
+		t.Errorf("Expected page mention of 'page-id', got %+v", page)
+	}
+}
+
+func TestNewPageComment(t *testing.T) {
+	req := NewPageComment("page-id", NewText("Hello "), NewUserMention("user-id"))
+	if req.Parent.PageID != "page-id" {
+		t.Errorf("Expected parent page 'page-id', got '%s'", req.Parent.PageID)
+	}
+	if len(req.RichText) != 2 {
+		t.Errorf("Expected 2 rich text elements, got %d", len(req.RichText))
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	text := PlainText([]RichText{NewText("a"), NewTextWithLink("b", "https://example.com"), {Text: &Text{Content: "c"}}})
+	if text != "abc" {
+		t.Errorf("Expected 'abc', got '%s'", text)
+	}
+}
+
+func TestNewMediaBlocks(t *testing.T) {
+	tests := map[string]*Block{
+		BlockTypeImage: NewImageBlock("https://example.com/a"),
+		BlockTypeVideo: NewVideoBlock("https://example.com/a"),
+		BlockTypePDF:   NewPDFBlock("https://example.com/a"),
+		BlockTypeAudio: NewAudioBlock("https://example.com/a"),
+		BlockTypeFile:  NewFileBlock("https://example.com/a"),
+	}
+	for blockType, block := range tests {
+		if block.Type != blockType {
+			t.Errorf("Expected type '%s', got '%s'", blockType, block.Type)
+		}
+		file := map[string]*FileBlock{
+			BlockTypeImage: block.Image,
+			BlockTypeVideo: block.Video,
+			BlockTypePDF:   block.PDF,
+			BlockTypeAudio: block.Audio,
+			BlockTypeFile:  block.File,
+		}[blockType]
+		if file == nil || file.Type != "external" || file.External.URL != "https://example.com/a" {
+			t.Errorf("Expected an external %s, got %+v", blockType, file)
+		}
+	}
+
+	if block := NewBookmarkBlock("https://example.com"); block.Bookmark.URL != "https://example.com" {
+		t.Errorf("Expected bookmark URL, got '%s'", block.Bookmark.URL)
+	}
+	if block := NewEmbedBlock("https://example.com"); block.Embed.URL != "https://example.com" {
+		t.Errorf("Expected embed URL, got '%s'", block.Embed.URL)
+	}
+}
+
+func TestNewLinkToPageBlocks(t *testing.T) {
+	page := NewLinkToPageBlock("page-id")
+	if page.LinkToPage.Type != "page_id" || page.LinkToPage.PageID != "page-id" {
+		t.Errorf("Expected a page link, got %+v", page.LinkToPage)
+	}
+	database := NewLinkToDatabaseBlock("db-id")
+	if database.LinkToPage.Type != "database_id" || database.LinkToPage.DatabaseID != "db-id" {
+		t.Errorf("Expected a database link, got %+v", database.LinkToPage)
+	}
+}
+
+func TestBlockWithChildrenAndColor(t *testing.T) {
+	toggle := NewToggleBlock([]RichText{NewText("Details")}).
+		WithChildren(NewParagraphBlock([]RichText{NewText("One")})).
+		WithChildren(NewEquationBlock("x^2")).
+		WithColor(ColorBlueBackground)
+
+	if len(toggle.Toggle.Children) != 2 || toggle.Toggle.Children[1].Equation.Expression != "x^2" {
+		t.Errorf("Expected 2 children, got %+v", toggle.Toggle.Children)
+	}
+	if toggle.Toggle.Color != ColorBlueBackground {
+		t.Errorf("Expected color '%s', got '%s'", ColorBlueBackground, toggle.Toggle.Color)
+	}
+
+	divider := NewDividerBlock().WithChildren(NewParagraphBlock(nil)).WithColor(ColorRed)
+	if divider.Children() != nil {
+		t.Error("Expected children to be ignored for a divider")
+	}
+
+	// The color follows Type, not whichever configuration is set
+	heading := NewHeading2Block([]RichText{NewText("Title")})
+	heading.Paragraph = &ParagraphBlock{}
+	heading.WithColor(ColorGreen)
+	if heading.Heading2.Color != ColorGreen || heading.Paragraph.Color != "" {
+		t.Errorf("Expected only the heading color to be set, got %+v and %+v", heading.Heading2, heading.Paragraph)
+	}
+}
+
+func TestNewColumnListBlock(t *testing.T) {
+	left := NewColumnBlock(NewParagraphBlock([]RichText{NewText("Left")}))
+	right := NewColumnBlock(NewImageBlock("https://example.com/a.png"))
+
+	columns, err := NewColumnListBlock(left, right)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(columns.ColumnList.Children) != 2 || columns.ColumnList.Children[1].Column.Children[0].Type != BlockTypeImage {
+		t.Errorf("Expected 2 columns, got %+v", columns.ColumnList.Children)
+	}
+
+	if _, err := NewColumnListBlock(left); err == nil {
+		t.Error("Expected an error for a single column")
+	}
+	if _, err := NewColumnListBlock(left, NewColumnBlock()); err == nil {
+		t.Error("Expected an error for an empty column")
+	}
+	if _, err := NewColumnListBlock(left, NewParagraphBlock(nil)); err == nil {
+		t.Error("Expected an error for a child that is not a column")
+	}
+}
+
+func TestNewSyncedBlocks(t *testing.T) {
+	original := NewSyncedBlock(NewParagraphBlock([]RichText{NewText("Shared")}))
+	if original.Synced.SyncedFrom != nil || len(original.Synced.Children) != 1 {
+		t.Errorf("Expected an original synced block with 1 child, got %+v", original.Synced)
+	}
+	reference := NewSyncedBlockReference("block-id")
+	if reference.Synced.SyncedFrom == nil || reference.Synced.SyncedFrom.BlockID != "block-id" {
+		t.Errorf("Expected a reference to block-id, got %+v", reference.Synced)
+	}
+
+	expected := `{"synced_from":null,"children":[{"object":"","id":"","type":"paragraph","paragraph":{"rich_text":[{"type":"text","text":{"content":"Shared"},"plain_text":"Shared"}]}}]}`
+	if got := blockPayload(t, original, "synced_block"); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+	expected = `{"synced_from":{"type":"block_id","block_id":"block-id"}}`
+	if got := blockPayload(t, reference, "synced_block"); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+// blockPayload returns the JSON of the given field of a marshalled block
+func blockPayload(t *testing.T, block *Block, field string) string {
+	t.Helper()
+	data, err := json.Marshal(block)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return string(fields[field])
+}
+
+func TestEmptyBlockPayloads(t *testing.T) {
+	blocks := map[string]*Block{
+		"divider":    NewDividerBlock(),
+		"breadcrumb": NewBreadcrumbBlock(),
+	}
+	for field, block := range blocks {
+		if got := blockPayload(t, block, field); got != "{}" {
+			t.Errorf("Expected %s to be sent as {}, got %q", field, got)
+		}
+	}
+	if got := blockPayload(t, &Block{Type: BlockTypeDivider}, "divider"); got != "{}" {
+		t.Errorf("Expected a divider without a configuration to be sent as {}, got %q", got)
+	}
+}
@@ -0,0 +1,850 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// MaxRichTextLength is the maximum length of a single rich text element accepted by Notion
+const MaxRichTextLength = notion.MaxRichTextLength
+
+var (
+	headingPattern   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))??(?:[ \t]+#+)?[ \t]*$`)
+	listItemPattern  = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])([ \t]+|$)`)
+	fencePattern     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`]*)$")
+	setextPattern    = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	delimiterPattern = regexp.MustCompile(`^[ \t]*:?-+:?[ \t]*$`)
+	autolinkPattern  = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+)
+
+// Parse converts Markdown into Notion blocks. It understands CommonMark
+// and the GFM extensions for tables, task lists and strikethrough. Rich text
+// longer than MaxRichTextLength is split into several elements.
+func Parse(markdown string) []notion.Block {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+	return parseBlocks(lines)
+}
+
+// ParseRichText converts inline Markdown into rich text. Emphasis, strong,
+// strikethrough and code become annotations, and links become linked text.
+func ParseRichText(text string) []notion.RichText {
+	var out []notion.RichText
+	parseInline(&out, text, style{})
+	return splitRichText(mergeRichText(out))
+}
+
+// parseBlocks parses lines into blocks
+func parseBlocks(lines []string) []notion.Block {
+	var blocks []notion.Block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case fencePattern.MatchString(line):
+			var block *notion.Block
+			block, i = parseFence(lines, i)
+			blocks = append(blocks, *block)
+		case strings.TrimSpace(line) == "$$":
+			var block *notion.Block
+			block, i = parseEquation(lines, i)
+			blocks = append(blocks, *block)
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, *newHeading(len(m[1]), ParseRichText(m[2])))
+			i++
+		case isThematicBreak(line):
+			blocks = append(blocks, *notion.NewDividerBlock())
+			i++
+		case isQuote(line):
+			var block *notion.Block
+			block, i = parseQuote(lines, i)
+			blocks = append(blocks, *block)
+		case listItemPattern.MatchString(line):
+			var block *notion.Block
+			block, i = parseListItem(lines, i)
+			blocks = append(blocks, *block)
+		case isTableStart(lines, i):
+			var block *notion.Block
+			block, i = parseTable(lines, i)
+			blocks = append(blocks, *block)
+		case indentation(line) >= 4:
+			var block *notion.Block
+			block, i = parseIndentedCode(lines, i)
+			blocks = append(blocks, *block)
+		default:
+			var block *notion.Block
+			block, i = parseParagraph(lines, i)
+			blocks = append(blocks, *block)
+		}
+	}
+	return blocks
+}
+
+// parseFence parses a fenced code block starting at lines[i]
+func parseFence(lines []string, i int) (*notion.Block, int) {
+	m := fencePattern.FindStringSubmatch(lines[i])
+	fence, info := m[1], strings.TrimSpace(m[2])
+	indent := indentation(lines[i])
+
+	var content []string
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		content = append(content, trimIndent(lines[i], indent))
+	}
+
+	var language string
+	if fields := strings.Fields(info); len(fields) > 0 {
+		language = fields[0]
+	}
+	return notion.NewCodeBlock(splitRichText([]notion.RichText{notion.NewText(strings.Join(content, "\n"))}), CodeLanguage(language)), i
+}
+
+// parseIndentedCode parses an indented code block starting at lines[i]
+func parseIndentedCode(lines []string, i int) (*notion.Block, int) {
+	var content []string
+	for ; i < len(lines) && (isBlank(lines[i]) || indentation(lines[i]) >= 4); i++ {
+		content = append(content, trimIndent(lines[i], 4))
+	}
+	for len(content) > 0 && isBlank(content[len(content)-1]) {
+		content = content[:len(content)-1]
+	}
+	return notion.NewCodeBlock(splitRichText([]notion.RichText{notion.NewText(strings.Join(content, "\n"))}), "plain text"), i
+}
+
+// parseEquation parses a $$ delimited block equation starting at lines[i]
+func parseEquation(lines []string, i int) (*notion.Block, int) {
+	var content []string
+	for i++; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "$$" {
+			i++
+			break
+		}
+		content = append(content, lines[i])
+	}
+	return &notion.Block{
+		Type:     notion.BlockTypeEquation,
+		Equation: &notion.EquationBlock{Expression: strings.TrimSpace(strings.Join(content, "\n"))},
+	}, i
+}
+
+// parseQuote parses a blockquote starting at lines[i]. The first paragraph
+// becomes the quote text and anything after it the quote's children.
+func parseQuote(lines []string, i int) (*notion.Block, int) {
+	var content []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if isQuote(line) {
+			line = strings.TrimLeft(line, " ")[1:]
+			line = strings.TrimPrefix(line, " ")
+		} else if isBlank(line) || len(content) == 0 || isBlank(content[len(content)-1]) || isBlockStart(line) {
+			break
+		}
+		content = append(content, line)
+	}
+
+	richText, children := splitLead(parseBlocks(content))
+	block := notion.NewQuoteBlock(richText)
+	block.Quote.Children = children
+	return block, i
+}
+
+// parseListItem parses a list item and its nested content starting at lines[i]
+func parseListItem(lines []string, i int) (*notion.Block, int) {
+	m := listItemPattern.FindStringSubmatch(lines[i])
+	marker := m[2]
+	offset := len(m[0])
+	if len(m[3]) > 4 {
+		// A wide gap means the content is an indented code block
+		offset = len(m[1]) + len(marker) + 1
+	}
+	if len(m[3]) == 0 {
+		offset = len(m[1]) + len(marker) + 1
+	}
+
+	var first string
+	if offset < len(lines[i]) {
+		first = lines[i][offset:]
+	}
+	content := []string{first}
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			// Blank lines belong to the item only if indented content follows
+			next := i + 1
+			for next < len(lines) && isBlank(lines[next]) {
+				next++
+			}
+			if next == len(lines) || indentation(lines[next]) < offset {
+				return newListItem(marker, content), i
+			}
+			content = append(content, "")
+		case indentation(line) >= offset:
+			content = append(content, line[offset:])
+		case !isBlank(content[len(content)-1]) && !isBlockStart(line):
+			// Lazy continuation of the item's paragraph
+			content = append(content, strings.TrimLeft(line, " "))
+		default:
+			return newListItem(marker, content), i
+		}
+	}
+	return newListItem(marker, content), i
+}
+
+// newListItem creates the block for a list item from its dedented content
+func newListItem(marker string, content []string) *notion.Block {
+	task, checked := false, false
+	if marker == "-" || marker == "*" || marker == "+" {
+		first := content[0]
+		switch {
+		case first == "[ ]" || strings.HasPrefix(first, "[ ] "):
+			task = true
+		case first == "[x]" || first == "[X]" || strings.HasPrefix(first, "[x] ") || strings.HasPrefix(first, "[X] "):
+			task, checked = true, true
+		}
+		if task {
+			content[0] = strings.TrimPrefix(first[3:], " ")
+		}
+	}
+
+	richText, children := splitLead(parseBlocks(content))
+	var block *notion.Block
+	switch {
+	case task:
+		block = notion.NewToDoBlock(richText, checked)
+	case marker == "-" || marker == "*" || marker == "+":
+		block = notion.NewBulletedListItemBlock(richText)
+	default:
+		block = notion.NewNumberedListItemBlock(richText)
+	}
+	if len(children) > 0 {
+		block.SetChildren(children)
+	}
+	return block
+}
+
+// isTableStart reports whether a GFM table starts at lines[i]
+func isTableStart(lines []string, i int) bool {
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	header := splitTableRow(lines[i])
+	delimiter := splitTableRow(lines[i+1])
+	if len(header) != len(delimiter) {
+		return false
+	}
+	for _, cell := range delimiter {
+		if !delimiterPattern.MatchString(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTable parses a GFM table starting at lines[i] into a table block
+// with its rows as children
+func parseTable(lines []string, i int) (*notion.Block, int) {
+	header := splitTableRow(lines[i])
+	width := len(header)
+	table := notion.NewTableBlock(width, true, false)
+
+	addRow := func(cells []string) {
+		row := make([][]notion.RichText, width)
+		for j := range row {
+			row[j] = []notion.RichText{}
+			if j < len(cells) {
+				row[j] = ParseRichText(cells[j])
+			}
+		}
+		table.Table.Children = append(table.Table.Children, *notion.NewTableRowBlock(row))
+	}
+
+	addRow(header)
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && !isBlockStart(lines[i]); i++ {
+		addRow(splitTableRow(lines[i]))
+	}
+	return table, i
+}
+
+// splitTableRow splits a table row into its trimmed cells
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, "\\|") {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '|':
+			cells = append(cells, strings.TrimSpace(line[start:i]))
+			start = i + 1
+		}
+	}
+	return append(cells, strings.TrimSpace(line[start:]))
+}
+
+// parseParagraph parses a paragraph starting at lines[i]. A paragraph that
+// consists of a single image becomes an image block.
+func parseParagraph(lines []string, i int) (*notion.Block, int) {
+	var parts []string
+	for start := i; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+		// A setext underline takes precedence over a thematic break
+		if m := setextPattern.FindStringSubmatch(line); m != nil && i > start {
+			level := 2
+			if m[1][0] == '=' {
+				level = 1
+			}
+			return newHeading(level, ParseRichText(joinLines(parts))), i + 1
+		}
+		if i > start && interruptsParagraph(line) {
+			break
+		}
+		parts = append(parts, strings.TrimLeft(line, " "))
+	}
+
+	content := joinLines(parts)
+	if image := parseImage(content); image != nil {
+		return image, i
+	}
+	return notion.NewParagraphBlock(ParseRichText(content)), i
+}
+
+// joinLines joins the lines of a paragraph. A line ending in two spaces or a
+// backslash is a hard line break, any other line break becomes a space.
+func joinLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		if i == len(lines)-1 {
+			b.WriteString(strings.TrimRight(line, " "))
+			break
+		}
+		switch {
+		case strings.HasSuffix(line, "  "):
+			b.WriteString(strings.TrimRight(line, " ") + "\n")
+		case strings.HasSuffix(line, "\\") && !strings.HasSuffix(line, "\\\\"):
+			b.WriteString(line[:len(line)-1] + "\n")
+		default:
+			b.WriteString(strings.TrimRight(line, " ") + " ")
+		}
+	}
+	return b.String()
+}
+
+// parseImage returns an image block if text is a single image
+func parseImage(text string) *notion.Block {
+	if !strings.HasPrefix(text, "![") {
+		return nil
+	}
+	alt, url, end, ok := parseLink(text, 1)
+	if !ok || end != len(text) {
+		return nil
+	}
+	var caption []notion.RichText
+	if alt != "" {
+		caption = ParseRichText(alt)
+	}
+	return &notion.Block{
+		Type: notion.BlockTypeImage,
+		Image: &notion.FileBlock{
+			Type:     "external",
+			External: &notion.File{URL: url},
+			Caption:  caption,
+		},
+	}
+}
+
+// splitLead separates a leading paragraph, whose rich text becomes the text
+// of an enclosing block, from the blocks that follow it
+func splitLead(blocks []notion.Block) ([]notion.RichText, []notion.Block) {
+	if len(blocks) > 0 && blocks[0].Type == notion.BlockTypeParagraph {
+		return blocks[0].Paragraph.RichText, blocks[1:]
+	}
+	return []notion.RichText{}, blocks
+}
+
+// newHeading creates a heading block. Levels beyond 3 become heading_3.
+func newHeading(level int, richText []notion.RichText) *notion.Block {
+	switch level {
+	case 1:
+		return notion.NewHeading1Block(richText)
+	case 2:
+		return notion.NewHeading2Block(richText)
+	}
+	return notion.NewHeading3Block(richText)
+}
+
+// isBlockStart reports whether line starts a block other than a paragraph
+func isBlockStart(line string) bool {
+	return headingPattern.MatchString(line) || fencePattern.MatchString(line) ||
+		isThematicBreak(line) || isQuote(line) || listItemPattern.MatchString(line) ||
+		strings.TrimSpace(line) == "$$"
+}
+
+// interruptsParagraph reports whether line ends a paragraph. Ordered lists
+// only interrupt a paragraph if they start at 1, and empty items never do.
+func interruptsParagraph(line string) bool {
+	if m := listItemPattern.FindStringSubmatch(line); m != nil {
+		if isBlank(line[len(m[0]):]) {
+			return false
+		}
+		marker := m[2]
+		return strings.ContainsAny(marker[:1], "-*+") || marker[:len(marker)-1] == "1"
+	}
+	return isBlockStart(line)
+}
+
+func isThematicBreak(line string) bool {
+	if indentation(line) > 3 {
+		return false
+	}
+	trimmed := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(trimmed) < 3 || !strings.ContainsAny(trimmed[:1], "-*_") {
+		return false
+	}
+	return strings.Trim(trimmed, trimmed[:1]) == ""
+}
+
+func isQuote(line string) bool {
+	return indentation(line) <= 3 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentation returns the number of leading spaces of line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimIndent removes up to n leading spaces from line
+func trimIndent(line string, n int) string {
+	if indent := indentation(line); indent < n {
+		n = indent
+	}
+	return line[n:]
+}
+
+// expandTabs replaces leading tabs with four spaces each
+func expandTabs(line string) string {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return strings.ReplaceAll(line[:i], "\t", "    ") + line[i:]
+}
+
+// style is the inline formatting applied to text while parsing
+type style struct {
+	bold, italic, strikethrough, code bool
+	link                              string
+}
+
+// parseInline appends the rich text for inline Markdown to out
+func parseInline(out *[]notion.RichText, s string, st style) {
+	var buf strings.Builder
+	flush := func() {
+		emit(out, buf.String(), st)
+		buf.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := runLength(s, i)
+			if end := closingBackticks(s, i+n, n); end >= 0 {
+				flush()
+				code := strings.ReplaceAll(s[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				codeStyle := st
+				codeStyle.code = true
+				emit(out, code, codeStyle)
+				i = end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case c == '*' || c == '_' || c == '~':
+			n := runLength(s, i)
+			delim := s[i : i+1]
+			if n >= 2 {
+				delim = s[i : i+2]
+			}
+			if c == '~' && n != 2 {
+				break
+			}
+			if end := closingDelimiter(s, i, delim); end >= 0 {
+				flush()
+				inner := st
+				switch {
+				case c == '~':
+					inner.strikethrough = true
+				case len(delim) == 2:
+					inner.bold = true
+				default:
+					inner.italic = true
+				}
+				parseInline(out, s[i+len(delim):end], inner)
+				i = end + len(delim)
+				continue
+			}
+
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			// Inline images become a link to the image
+			if alt, url, end, ok := parseLink(s, i+1); ok {
+				flush()
+				linked := st
+				linked.link = url
+				if alt == "" {
+					alt = url
+				}
+				parseInline(out, alt, linked)
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if text, url, end, ok := parseLink(s, i); ok {
+				flush()
+				linked := st
+				linked.link = url
+				parseInline(out, text, linked)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if m := autolinkPattern.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				linked := st
+				linked.link = m[1]
+				emit(out, m[1], linked)
+				i += len(m[0])
+				continue
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+}
+
+// emit appends a rich text element for text in the given style
+func emit(out *[]notion.RichText, text string, st style) {
+	if text == "" {
+		return
+	}
+	rt := notion.NewText(text)
+	if st.link != "" {
+		rt = notion.NewTextWithLink(text, st.link)
+	}
+	if st.bold || st.italic || st.strikethrough || st.code {
+		rt.Annotations = &notion.Annotations{
+			Bold:          st.bold,
+			Italic:        st.italic,
+			Strikethrough: st.strikethrough,
+			Code:          st.code,
+			Color:         notion.ColorDefault,
+		}
+	}
+	*out = append(*out, rt)
+}
+
+// parseLink parses [text](destination) starting at the bracket s[i]. It
+// returns the link text, the destination and the index after the link.
+func parseLink(s string, i int) (string, string, int, bool) {
+	closeBracket := matching(s, i, '[', ']')
+	if closeBracket < 0 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return "", "", 0, false
+	}
+	closeParen := matching(s, closeBracket+1, '(', ')')
+	if closeParen < 0 {
+		return "", "", 0, false
+	}
+
+	dest := strings.TrimSpace(s[closeBracket+2 : closeParen])
+	if strings.HasPrefix(dest, "<") {
+		if end := strings.Index(dest, ">"); end > 0 {
+			dest = dest[1:end]
+		}
+	} else if space := strings.IndexAny(dest, " \t\n"); space >= 0 {
+		// Drop the optional link title
+		dest = dest[:space]
+	}
+	return s[i+1 : closeBracket], dest, closeParen + 1, true
+}
+
+// matching returns the index of the bracket closing the one at s[i], skipping escapes
+func matching(s string, i int, open, close byte) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// closingBackticks returns the start of a backtick run of exactly n at or after i
+func closingBackticks(s string, i, n int) int {
+	for i < len(s) {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		run := runLength(s, i)
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// closingDelimiter returns the index of the delimiter closing the one
+// opened at s[i], or -1. Delimiters must hug the text they enclose, and
+// underscores may not open or close inside a word.
+func closingDelimiter(s string, i int, delim string) int {
+	start := i + len(delim)
+	if start >= len(s) || isSpace(s[start]) {
+		return -1
+	}
+	if delim[0] == '_' && i > 0 && isWordChar(s[i-1]) {
+		return -1
+	}
+
+	for j := start + 1; j+len(delim) <= len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+			continue
+		case s[j] == '`':
+			// Code spans take precedence over emphasis
+			n := runLength(s, j)
+			if end := closingBackticks(s, j+n, n); end >= 0 {
+				j = end + n - 1
+			}
+			continue
+		case s[j:j+len(delim)] != delim || isSpace(s[j-1]):
+			continue
+		}
+
+		// Close at the end of a longer run, so ***text*** nests correctly
+		for j+len(delim) < len(s) && s[j+len(delim)] == delim[0] {
+			j++
+		}
+		if len(delim) == 1 && s[j-1] == delim[0] {
+			continue
+		}
+		if delim[0] == '_' && j+len(delim) < len(s) && isWordChar(s[j+len(delim)]) {
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+// runLength returns the number of consecutive s[i] bytes starting at i
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// mergeRichText joins adjacent elements that share annotations and link
+func mergeRichText(richText []notion.RichText) []notion.RichText {
+	merged := make([]notion.RichText, 0, len(richText))
+	for _, rt := range richText {
+		if n := len(merged); n > 0 && sameStyle(merged[n-1], rt) {
+			last := &merged[n-1]
+			text := *last.Text
+			text.Content += rt.Text.Content
+			last.Text = &text
+			last.PlainText += rt.PlainText
+			continue
+		}
+		merged = append(merged, rt)
+	}
+	return merged
+}
+
+func sameStyle(a, b notion.RichText) bool {
+	if a.Href != b.Href {
+		return false
+	}
+	if a.Annotations == nil || b.Annotations == nil {
+		return a.Annotations == b.Annotations
+	}
+	return *a.Annotations == *b.Annotations
+}
+
+// splitRichText splits text elements longer than MaxRichTextLength
+// characters into several elements with the same formatting
+func splitRichText(richText []notion.RichText) []notion.RichText {
+	var out []notion.RichText
+	for _, rt := range richText {
+		if rt.Text == nil {
+			out = append(out, rt)
+			continue
+		}
+		runes := []rune(rt.Text.Content)
+		for len(runes) > MaxRichTextLength {
+			out = append(out, withContent(rt, string(runes[:MaxRichTextLength])))
+			runes = runes[MaxRichTextLength:]
+		}
+		out = append(out, withContent(rt, string(runes)))
+	}
+	if out == nil {
+		return []notion.RichText{}
+	}
+	return out
+}
+
+// withContent returns a copy of a text element with different content
+func withContent(rt notion.RichText, content string) notion.RichText {
+	text := *rt.Text
+	text.Content = content
+	rt.Text = &text
+	rt.PlainText = content
+	return rt
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isWordChar(c byte) bool {
+	return c >= 0x80 || c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// CodeLanguage maps a Markdown code fence info string to a Notion code
+// language. Unknown languages map to "plain text".
+func CodeLanguage(info string) string {
+	info = strings.ToLower(info)
+	if language, ok := languageAliases[info]; ok {
+		return language
+	}
+	if notionLanguages[info] {
+		return info
+	}
+	return "plain text"
+}
+
+// languageAliases maps common info strings to Notion code languages
+var languageAliases = map[string]string{
+	"":           "plain text",
+	"text":       "plain text",
+	"txt":        "plain text",
+	"plaintext":  "plain text",
+	"js":         "javascript",
+	"jsx":        "javascript",
+	"mjs":        "javascript",
+	"ts":         "typescript",
+	"tsx":        "typescript",
+	"py":         "python",
+	"rb":         "ruby",
+	"sh":         "shell",
+	"zsh":        "shell",
+	"console":    "shell",
+	"golang":     "go",
+	"yml":        "yaml",
+	"cpp":        "c++",
+	"cc":         "c++",
+	"cxx":        "c++",
+	"hpp":        "c++",
+	"csharp":     "c#",
+	"cs":         "c#",
+	"fsharp":     "f#",
+	"fs":         "f#",
+	"objectivec": "objective-c",
+	"objc":       "objective-c",
+	"dockerfile": "docker",
+	"make":       "makefile",
+	"md":         "markdown",
+	"tex":        "latex",
+	"kt":         "kotlin",
+	"rs":         "rust",
+	"ps1":        "powershell",
+	"pwsh":       "powershell",
+	"proto":      "protobuf",
+	"vbnet":      "vb.net",
+	"vb":         "visual basic",
+	"hs":         "haskell",
+	"ex":         "elixir",
+	"exs":        "elixir",
+	"erl":        "erlang",
+	"clj":        "clojure",
+	"coffee":     "coffeescript",
+	"htm":        "html",
+	"svg":        "xml",
+	"wasm":       "webassembly",
+	"jl":         "julia",
+	"ml":         "ocaml",
+	"pl":         "perl",
+	"gql":        "graphql",
+	"patch":      "diff",
+}
+
+// notionLanguages is the set of code languages supported by Notion
+var notionLanguages = map[string]bool{
+	"abap": true, "arduino": true, "bash": true, "basic": true, "c": true,
+	"clojure": true, "coffeescript": true, "c++": true, "c#": true, "css": true,
+	"dart": true, "diff": true, "docker": true, "elixir": true, "elm": true,
+	"erlang": true, "flow": true, "fortran": true, "f#": true, "gherkin": true,
+	"glsl": true, "go": true, "graphql": true, "groovy": true, "haskell": true,
+	"html": true, "java": true, "javascript": true, "json": true, "julia": true,
+	"kotlin": true, "latex": true, "less": true, "lisp": true, "livescript": true,
+	"lua": true, "makefile": true, "markdown": true, "markup": true, "matlab": true,
+	"mermaid": true, "nix": true, "objective-c": true, "ocaml": true, "pascal": true,
+	"perl": true, "php": true, "plain text": true, "powershell": true, "prolog": true,
+	"protobuf": true, "python": true, "r": true, "reason": true, "ruby": true,
+	"rust": true, "sass": true, "scala": true, "scheme": true, "scss": true,
+	"shell": true, "sql": true, "swift": true, "typescript": true, "vb.net": true,
+	"verilog": true, "vhdl": true, "visual basic": true, "webassembly": true,
+	"xml": true, "yaml": true, "java/c/c++/c#": true,
+}
@@ -0,0 +1,175 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wujie1993/go-notion"
+)
+
+func TestParseBlocks(t *testing.T) {
+	src := strings.Join([]string{
+		"# Release notes",
+		"",
+		"Some *intro* text",
+		"over two lines.",
+		"",
+		"## Changes",
+		"",
+		"- First",
+		"  - Nested **bold**",
+		"- [x] Shipped",
+		"- [ ] Pending",
+		"1. One",
+		"2. Two",
+		"",
+		"```golang",
+		"fmt.Println(\"hi\")",
+		"```",
+		"",
+		"> Quoted",
+		"",
+		"---",
+		"",
+		"![Screenshot](https://example.com/shot.png)",
+	}, "\n")
+
+	blocks := Parse(src)
+	types := make([]string, len(blocks))
+	for i, block := range blocks {
+		types[i] = block.Type
+	}
+	expected := "heading_1 paragraph heading_2 bulleted_list_item to_do to_do numbered_list_item numbered_list_item code quote divider image"
+	if got := strings.Join(types, " "); got != expected {
+		t.Fatalf("Expected %s, got %s", expected, got)
+	}
+
+	if text := notion.PlainText(blocks[1].Paragraph.RichText); text != "Some intro text over two lines." {
+		t.Errorf("Expected paragraph lines to be joined, got %q", text)
+	}
+	nested := blocks[3].BulletedListItem.Children
+	if len(nested) != 1 || nested[0].Type != notion.BlockTypeBulletedListItem {
+		t.Fatalf("Expected a nested list item, got %+v", nested)
+	}
+	if rt := nested[0].BulletedListItem.RichText; len(rt) != 2 || rt[1].Annotations == nil || !rt[1].Annotations.Bold {
+		t.Errorf("Expected bold nested text, got %+v", rt)
+	}
+	if !blocks[4].ToDo.Checked || blocks[5].ToDo.Checked {
+		t.Error("Expected the first task to be checked and the second not")
+	}
+	if blocks[8].Code.Language != "go" || notion.PlainText(blocks[8].Code.RichText) != `fmt.Println("hi")` {
+		t.Errorf("Expected a go code block, got %+v", blocks[8].Code)
+	}
+	if blocks[11].Image.External.URL != "https://example.com/shot.png" || notion.PlainText(blocks[11].Image.Caption) != "Screenshot" {
+		t.Errorf("Expected an external image with a caption, got %+v", blocks[11].Image)
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	blocks := Parse("| Name | Value |\n| :--- | ---: |\n| a \\| b | `1` |\n| c |\n")
+	if len(blocks) != 1 || blocks[0].Type != notion.BlockTypeTable {
+		t.Fatalf("Expected a table, got %+v", blocks)
+	}
+	table := blocks[0].Table
+	if table.TableWidth != 2 || !table.HasColumnHeader || len(table.Children) != 3 {
+		t.Fatalf("Expected a 2 column table with a header and 3 rows, got %+v", table)
+	}
+	cells := table.Children[1].TableRow.Cells
+	if notion.PlainText(cells[0]) != "a | b" || !cells[1][0].Annotations.Code {
+		t.Errorf("Expected escaped pipe and code cell, got %+v", cells)
+	}
+	if short := table.Children[2].TableRow.Cells; len(short) != 2 || len(short[1]) != 0 {
+		t.Errorf("Expected short rows to be padded, got %+v", short)
+	}
+}
+
+func TestParseSetextHeadings(t *testing.T) {
+	blocks := Parse("Title\n===\n\nSubtitle\n---\n\n---\n\nText\n\n***")
+	types := make([]string, len(blocks))
+	for i, block := range blocks {
+		types[i] = block.Type
+	}
+	expected := "heading_1 heading_2 divider paragraph divider"
+	if got := strings.Join(types, " "); got != expected {
+		t.Fatalf("Expected %s, got %s", expected, got)
+	}
+	if text := notion.PlainText(blocks[1].Heading2.RichText); text != "Subtitle" {
+		t.Errorf("Expected the heading text Subtitle, got %q", text)
+	}
+}
+
+func TestParseRichText(t *testing.T) {
+	rt := ParseRichText("plain **bold *both*** ~~gone~~ `a*b` [link](https://example.com \"title\") \\*literal\\*")
+
+	type span struct {
+		text                       string
+		bold, italic, strike, code bool
+		href                       string
+	}
+	var got []span
+	for _, r := range rt {
+		s := span{text: r.Text.Content, href: r.Href}
+		if a := r.Annotations; a != nil {
+			s.bold, s.italic, s.strike, s.code = a.Bold, a.Italic, a.Strikethrough, a.Code
+		}
+		got = append(got, s)
+	}
+
+	expected := []span{
+		{text: "plain "},
+		{text: "bold ", bold: true},
+		{text: "both", bold: true, italic: true},
+		{text: " "},
+		{text: "gone", strike: true},
+		{text: " "},
+		{text: "a*b", code: true},
+		{text: " "},
+		{text: "link", href: "https://example.com"},
+		{text: " *literal*"},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d spans, got %d: %+v", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Span %d: expected %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+}
+
+func TestParseSplitsLongText(t *testing.T) {
+	long := strings.Repeat("é", MaxRichTextLength+10)
+	blocks := Parse(long)
+	rt := blocks[0].Paragraph.RichText
+	if len(rt) != 2 {
+		t.Fatalf("Expected 2 rich text elements, got %d", len(rt))
+	}
+	if n := len([]rune(rt[0].Text.Content)); n != MaxRichTextLength {
+		t.Errorf("Expected the first element to hold %d characters, got %d", MaxRichTextLength, n)
+	}
+	if notion.PlainText(rt) != long {
+		t.Error("Expected the split text to join back to the original")
+	}
+}
+
+func TestCodeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"go":      "go",
+		"JS":      "javascript",
+		"cpp":     "c++",
+		"":        "plain text",
+		"unknown": "plain text",
+	}
+	for info, expected := range tests {
+		if got := CodeLanguage(info); got != expected {
+			t.Errorf("CodeLanguage(%q): expected %q, got %q", info, expected, got)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	src := "# Title\n\nSome **bold** and *italic* text\n\n- One\n  - Two\n- [x] Done\n\n```go\nx := 1\n```\n\n| A | B |\n| --- | --- |\n| 1 | 2 |\n"
+	if got := Render(Parse(src)); got != src {
+		t.Errorf("Expected round trip to be lossless:\n%s\ngot:\n%s", src, got)
+	}
+}
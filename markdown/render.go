@@ -0,0 +1,572 @@
+// Package markdown converts between Notion blocks and Markdown.
+package markdown
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// Flavor selects the Markdown dialect produced by the renderer
+type Flavor int
+
+// Markdown flavors
+const (
+	// GFM is GitHub Flavored Markdown, with pipe tables and ~~strikethrough~~
+	GFM Flavor = iota
+	// CommonMark is plain CommonMark. Tables and strikethrough fall back to HTML.
+	CommonMark
+)
+
+// BlockHook renders a single block. It receives the renderer so that it can
+// render the block's children or rich text.
+type BlockHook func(r *Renderer, block *notion.Block) string
+
+// Options configures a Renderer
+type Options struct {
+	// Flavor is the Markdown dialect to produce. Defaults to GFM.
+	Flavor Flavor
+	// Hooks render blocks by type. A hook takes precedence over the
+	// built-in rendering, so it can also be used to override it.
+	Hooks map[string]BlockHook
+	// Unsupported renders blocks that have neither a hook nor a built-in
+	// rendering, such as child_page or link_to_page. If nil they are dropped.
+	Unsupported BlockHook
+}
+
+// Renderer renders Notion blocks as Markdown
+type Renderer struct {
+	opts Options
+}
+
+// NewRenderer creates a new Renderer
+func NewRenderer(opts *Options) *Renderer {
+	r := &Renderer{}
+	if opts != nil {
+		r.opts = *opts
+	}
+	return r
+}
+
+// Render renders blocks as GitHub Flavored Markdown
+func Render(blocks []notion.Block) string {
+	return NewRenderer(nil).Render(blocks)
+}
+
+// Render renders a block tree as Markdown. Children must already be
+// populated, for example with Client.GetBlockTree.
+func (r *Renderer) Render(blocks []notion.Block) string {
+	out := r.renderBlocks(blocks)
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+// RenderRichText renders rich text as inline Markdown
+func (r *Renderer) RenderRichText(richText []notion.RichText) string {
+	var b strings.Builder
+	for _, rt := range richText {
+		b.WriteString(r.renderSpan(rt))
+	}
+	return b.String()
+}
+
+// renderBlocks renders blocks separated by blank lines, without a trailing newline
+func (r *Renderer) renderBlocks(blocks []notion.Block) string {
+	var parts []string
+	for i := 0; i < len(blocks); {
+		if isListItem(&blocks[i]) {
+			j := i + 1
+			for j < len(blocks) && sameList(&blocks[i], &blocks[j]) {
+				j++
+			}
+			parts = append(parts, r.renderList(blocks[i:j]))
+			i = j
+			continue
+		}
+		if out := r.renderBlock(&blocks[i]); out != "" {
+			parts = append(parts, out)
+		}
+		i++
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// renderBlock renders a block that is not part of a list
+func (r *Renderer) renderBlock(block *notion.Block) string {
+	if hook, ok := r.opts.Hooks[block.Type]; ok {
+		return hook(r, block)
+	}
+
+	switch block.Type {
+	case notion.BlockTypeParagraph:
+		if block.Paragraph != nil {
+			return r.withChildren(r.RenderRichText(block.Paragraph.RichText), block.Paragraph.Children)
+		}
+	case notion.BlockTypeHeading1:
+		if block.Heading1 != nil {
+			return r.renderHeading(1, block.Heading1)
+		}
+	case notion.BlockTypeHeading2:
+		if block.Heading2 != nil {
+			return r.renderHeading(2, block.Heading2)
+		}
+	case notion.BlockTypeHeading3:
+		if block.Heading3 != nil {
+			return r.renderHeading(3, block.Heading3)
+		}
+	case notion.BlockTypeToggle:
+		if block.Toggle != nil {
+			return r.renderDetails(block.Toggle.RichText, block.Toggle.Children)
+		}
+	case notion.BlockTypeQuote:
+		if block.Quote != nil {
+			return quote(r.withChildren(r.RenderRichText(block.Quote.RichText), block.Quote.Children))
+		}
+	case notion.BlockTypeCallout:
+		if block.Callout != nil {
+			text := r.RenderRichText(block.Callout.RichText)
+			if icon := block.Callout.Icon; icon != nil && icon.Emoji != "" {
+				text = icon.Emoji + " " + text
+			}
+			return quote(r.withChildren(text, block.Callout.Children))
+		}
+	case notion.BlockTypeCode:
+		if block.Code != nil {
+			return renderCode(block.Code)
+		}
+	case notion.BlockTypeEquation:
+		if block.Equation != nil {
+			return "$$\n" + block.Equation.Expression + "\n$$"
+		}
+	case notion.BlockTypeDivider:
+		return "---"
+	case notion.BlockTypeTable:
+		if block.Table != nil {
+			if r.opts.Flavor == CommonMark {
+				return renderHTMLTable(block.Table)
+			}
+			return r.renderPipeTable(block.Table)
+		}
+	case notion.BlockTypeImage:
+		if block.Image != nil {
+			return "![" + escape(notion.PlainText(block.Image.Caption)) + "](" + destination(fileURL(block.Image)) + ")"
+		}
+	case notion.BlockTypeVideo, notion.BlockTypeFile, notion.BlockTypePDF, notion.BlockTypeAudio:
+		if file := fileBlock(block); file != nil {
+			return r.renderLink(file.Caption, fileURL(file))
+		}
+	case notion.BlockTypeBookmark:
+		if block.Bookmark != nil {
+			return r.renderLink(block.Bookmark.Caption, block.Bookmark.URL)
+		}
+	case notion.BlockTypeEmbed:
+		if block.Embed != nil {
+			return r.renderLink(block.Embed.Caption, block.Embed.URL)
+		}
+	case notion.BlockTypeLinkPreview:
+		if block.LinkPreview != nil {
+			return r.renderLink(nil, block.LinkPreview.URL)
+		}
+	case notion.BlockTypeColumnList, notion.BlockTypeColumn, notion.BlockTypeSynced:
+		// Layout containers render their content in order
+		return r.renderBlocks(block.Children())
+	}
+
+	if r.opts.Unsupported != nil {
+		return r.opts.Unsupported(r, block)
+	}
+	return ""
+}
+
+// renderHeading renders a heading, as a <details> element if it is toggleable
+func (r *Renderer) renderHeading(level int, heading *notion.HeadingBlock) string {
+	if heading.IsToggleable {
+		summary := "<h" + strconv.Itoa(level) + ">" + html.EscapeString(notion.PlainText(heading.RichText)) + "</h" + strconv.Itoa(level) + ">"
+		return detailsHTML(summary, r.renderBlocks(heading.Children))
+	}
+	return r.withChildren(strings.Repeat("#", level)+" "+r.RenderRichText(heading.RichText), heading.Children)
+}
+
+// renderDetails renders a toggle as a <details> element
+func (r *Renderer) renderDetails(summary []notion.RichText, children []notion.Block) string {
+	return detailsHTML(html.EscapeString(notion.PlainText(summary)), r.renderBlocks(children))
+}
+
+func detailsHTML(summary, body string) string {
+	out := "<details>\n<summary>" + summary + "</summary>"
+	if body != "" {
+		out += "\n\n" + body + "\n"
+	}
+	return out + "\n</details>"
+}
+
+// withChildren renders children as blocks following text
+func (r *Renderer) withChildren(text string, children []notion.Block) string {
+	body := r.renderBlocks(children)
+	switch {
+	case body == "":
+		return text
+	case text == "":
+		return body
+	}
+	return text + "\n\n" + body
+}
+
+// renderList renders consecutive list items of the same list
+func (r *Renderer) renderList(items []notion.Block) string {
+	lines := make([]string, 0, len(items))
+	for i := range items {
+		item := &items[i]
+		if hook, ok := r.opts.Hooks[item.Type]; ok {
+			lines = append(lines, hook(r, item))
+			continue
+		}
+
+		var marker string
+		var richText []notion.RichText
+		switch item.Type {
+		case notion.BlockTypeNumberedListItem:
+			marker = strconv.Itoa(i+1) + ". "
+			richText = item.NumberedListItem.RichText
+		case notion.BlockTypeToDo:
+			marker = "- [ ] "
+			if item.ToDo.Checked {
+				marker = "- [x] "
+			}
+			richText = item.ToDo.RichText
+		default:
+			marker = "- "
+			richText = item.BulletedListItem.RichText
+		}
+
+		// Task list items start their content after the bullet, not the checkbox
+		width := len(marker)
+		if item.Type == notion.BlockTypeToDo {
+			width = 2
+		}
+		out := marker + indent(r.RenderRichText(richText), width, false)
+		if children := item.Children(); len(children) > 0 {
+			separator := "\n\n"
+			if isListItem(&children[0]) {
+				separator = "\n"
+			}
+			out += separator + indent(r.renderBlocks(children), width, true)
+		}
+		lines = append(lines, out)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderPipeTable renders a table as a GFM pipe table. Tables without a
+// column header get an empty header row, since GFM requires one.
+func (r *Renderer) renderPipeTable(table *notion.TableBlock) string {
+	rows := tableRows(table)
+	width := tableWidth(table, rows)
+	if width == 0 {
+		return ""
+	}
+
+	var lines []string
+	if !table.HasColumnHeader || len(rows) == 0 {
+		lines = append(lines, r.pipeRow(nil, width))
+	} else {
+		lines = append(lines, r.pipeRow(rows[0], width))
+		rows = rows[1:]
+	}
+	lines = append(lines, "|"+strings.Repeat(" --- |", width))
+	for _, row := range rows {
+		lines = append(lines, r.pipeRow(row, width))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Renderer) pipeRow(cells [][]notion.RichText, width int) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i := 0; i < width; i++ {
+		b.WriteString(" ")
+		if i < len(cells) {
+			cell := strings.ReplaceAll(r.RenderRichText(cells[i]), "|", "\\|")
+			b.WriteString(strings.ReplaceAll(cell, "\n", "<br>"))
+		}
+		b.WriteString(" |")
+	}
+	return b.String()
+}
+
+// renderHTMLTable renders a table as an HTML table for CommonMark
+func renderHTMLTable(table *notion.TableBlock) string {
+	rows := tableRows(table)
+	width := tableWidth(table, rows)
+	if width == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("<table>\n")
+	for i, row := range rows {
+		b.WriteString("<tr>")
+		for j := 0; j < width; j++ {
+			tag := "td"
+			if (i == 0 && table.HasColumnHeader) || (j == 0 && table.HasRowHeader) {
+				tag = "th"
+			}
+			var text string
+			if j < len(row) {
+				text = html.EscapeString(notion.PlainText(row[j]))
+			}
+			b.WriteString("<" + tag + ">" + text + "</" + tag + ">")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>")
+	return b.String()
+}
+
+func tableRows(table *notion.TableBlock) [][][]notion.RichText {
+	var rows [][][]notion.RichText
+	for _, child := range table.Children {
+		if child.Type == notion.BlockTypeTableRow && child.TableRow != nil {
+			rows = append(rows, child.TableRow.Cells)
+		}
+	}
+	return rows
+}
+
+func tableWidth(table *notion.TableBlock, rows [][][]notion.RichText) int {
+	width := table.TableWidth
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// renderCode renders a fenced code block
+func renderCode(code *notion.CodeBlock) string {
+	content := notion.PlainText(code.RichText)
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	return fence + codeLanguage(code.Language) + "\n" + content + "\n" + fence
+}
+
+// codeLanguage maps a Notion code language to a Markdown info string
+func codeLanguage(language string) string {
+	switch language {
+	case "plain text", "":
+		return ""
+	case "c++":
+		return "cpp"
+	case "c#":
+		return "csharp"
+	case "f#":
+		return "fsharp"
+	case "objective-c":
+		return "objectivec"
+	case "vb.net", "visual basic":
+		return "vbnet"
+	}
+	return strings.ReplaceAll(language, " ", "-")
+}
+
+// renderLink renders a link to url, using the caption as link text
+func (r *Renderer) renderLink(caption []notion.RichText, url string) string {
+	if url == "" {
+		return ""
+	}
+	if len(caption) == 0 {
+		return "<" + url + ">"
+	}
+	return "[" + r.RenderRichText(caption) + "](" + destination(url) + ")"
+}
+
+// renderSpan renders a single rich text element
+func (r *Renderer) renderSpan(rt notion.RichText) string {
+	text := rt.PlainText
+	if text == "" && rt.Text != nil {
+		text = rt.Text.Content
+	}
+	if rt.Type == "equation" && rt.Equation != nil {
+		return "$" + rt.Equation.Expression + "$"
+	}
+	if text == "" {
+		return ""
+	}
+
+	a := rt.Annotations
+	if a == nil {
+		a = &notion.Annotations{}
+	}
+
+	// Emphasis markers must hug the text, so surrounding whitespace is kept outside
+	lead := text[:len(text)-len(strings.TrimLeft(text, " \t\n"))]
+	text = strings.TrimLeft(text, " \t\n")
+	trail := text[len(strings.TrimRight(text, " \t\n")):]
+	text = strings.TrimRight(text, " \t\n")
+	if text == "" {
+		return lead + trail
+	}
+
+	if a.Code {
+		text = codeSpan(text)
+	} else {
+		text = strings.ReplaceAll(escape(text), "\n", "\\\n")
+	}
+	if a.Bold {
+		text = "**" + text + "**"
+	}
+	if a.Italic {
+		text = "*" + text + "*"
+	}
+	if a.Strikethrough {
+		if r.opts.Flavor == CommonMark {
+			text = "<del>" + text + "</del>"
+		} else {
+			text = "~~" + text + "~~"
+		}
+	}
+	if a.Underline {
+		text = "<u>" + text + "</u>"
+	}
+
+	href := rt.Href
+	if rt.Text != nil && rt.Text.Link != nil {
+		href = rt.Text.Link.URL
+	}
+	if href != "" {
+		text = "[" + text + "](" + destination(href) + ")"
+	}
+	return lead + text + trail
+}
+
+// codeSpan wraps text in enough backticks to contain it
+func codeSpan(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+	return fence + text + fence
+}
+
+// escape backslash-escapes characters that Markdown would interpret
+func escape(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = escapeLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func escapeLine(line string) string {
+	var b strings.Builder
+	for _, c := range line {
+		if strings.ContainsRune("\\`*_[]<>~", c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	out := b.String()
+
+	// Block markers are only special at the start of a line
+	start := len(out) - len(strings.TrimLeft(out, " "))
+	rest := out[start:]
+	if rest != "" && strings.ContainsRune("#-+=", rune(rest[0])) {
+		return out[:start] + "\\" + rest
+	}
+	digits := len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+	if digits > 0 && digits < len(rest) && (rest[digits] == '.' || rest[digits] == ')') {
+		return out[:start+digits] + "\\" + out[start+digits:]
+	}
+	return out
+}
+
+// destination formats a link destination, wrapping it in <> if needed
+func destination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+	return url
+}
+
+// quote prefixes every line with a blockquote marker
+func quote(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indent indents every line after the first by n spaces, and the first
+// line too if first is set. Empty lines are left empty.
+func indent(text string, n int, first bool) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" && (i > 0 || first) {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isListItem(block *notion.Block) bool {
+	switch block.Type {
+	case notion.BlockTypeBulletedListItem:
+		return block.BulletedListItem != nil
+	case notion.BlockTypeNumberedListItem:
+		return block.NumberedListItem != nil
+	case notion.BlockTypeToDo:
+		return block.ToDo != nil
+	}
+	return false
+}
+
+// sameList reports whether b continues the list started by a. Bulleted
+// items and to-dos share a list, numbered items form their own.
+func sameList(a, b *notion.Block) bool {
+	if !isListItem(b) {
+		return false
+	}
+	return (a.Type == notion.BlockTypeNumberedListItem) == (b.Type == notion.BlockTypeNumberedListItem)
+}
+
+func fileBlock(block *notion.Block) *notion.FileBlock {
+	switch block.Type {
+	case notion.BlockTypeImage:
+		return block.Image
+	case notion.BlockTypeVideo:
+		return block.Video
+	case notion.BlockTypeFile:
+		return block.File
+	case notion.BlockTypePDF:
+		return block.PDF
+	case notion.BlockTypeAudio:
+		return block.Audio
+	}
+	return nil
+}
+
+func fileURL(file *notion.FileBlock) string {
+	if file.External != nil {
+		return file.External.URL
+	}
+	if file.File != nil {
+		return file.File.URL
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wujie1993/go-notion"
+)
+
+func text(content string) []notion.RichText {
+	return []notion.RichText{notion.NewText(content)}
+}
+
+func withChildren(block *notion.Block, children ...*notion.Block) notion.Block {
+	blocks := make([]notion.Block, len(children))
+	for i, child := range children {
+		blocks[i] = *child
+	}
+	block.SetChildren(blocks)
+	return *block
+}
+
+func TestRenderBlocks(t *testing.T) {
+	blocks := []notion.Block{
+		*notion.NewHeading1Block(text("Title")),
+		*notion.NewParagraphBlock([]notion.RichText{
+			notion.NewText("Plain "),
+			notion.NewAnnotatedText("bold ", &notion.Annotations{Bold: true}),
+			notion.NewAnnotatedText("code", &notion.Annotations{Code: true}),
+			notion.NewText(" and "),
+			notion.NewTextWithLink("a link", "https://example.com"),
+			notion.NewText(" "),
+			notion.NewAnnotatedText("gone", &notion.Annotations{Strikethrough: true, Italic: true}),
+		}),
+		withChildren(notion.NewBulletedListItemBlock(text("One")),
+			notion.NewNumberedListItemBlock(text("Nested")),
+			notion.NewNumberedListItemBlock(text("Second")),
+		),
+		*notion.NewBulletedListItemBlock(text("Two")),
+		*notion.NewToDoBlock(text("Done"), true),
+		*notion.NewToDoBlock(text("Open"), false),
+		*notion.NewCodeBlock(text("fmt.Println(1)"), "go"),
+		*notion.NewQuoteBlock(text("Quoted")),
+		*notion.NewCalloutBlock(text("Careful"), notion.NewEmojiIcon("⚠️")),
+		*notion.NewDividerBlock(),
+		{Type: notion.BlockTypeEquation, Equation: &notion.EquationBlock{Expression: "e=mc^2"}},
+		{Type: notion.BlockTypeImage, Image: &notion.FileBlock{
+			Type:     "external",
+			External: &notion.File{URL: "https://example.com/a.png"},
+			Caption:  text("Diagram"),
+		}},
+		{Type: notion.BlockTypeBookmark, Bookmark: &notion.BookmarkBlock{URL: "https://example.com"}},
+	}
+
+	expected := strings.Join([]string{
+		"# Title",
+		"",
+		"Plain **bold** `code` and [a link](https://example.com) ~~*gone*~~",
+		"",
+		"- One",
+		"  1. Nested",
+		"  2. Second",
+		"- Two",
+		"- [x] Done",
+		"- [ ] Open",
+		"",
+		"```go",
+		"fmt.Println(1)",
+		"```",
+		"",
+		"> Quoted",
+		"",
+		"> ⚠️ Careful",
+		"",
+		"---",
+		"",
+		"$$",
+		"e=mc^2",
+		"$$",
+		"",
+		"![Diagram](https://example.com/a.png)",
+		"",
+		"<https://example.com>",
+		"",
+	}, "\n")
+
+	if got := Render(blocks); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestRenderToggle(t *testing.T) {
+	toggle := notion.Block{Type: notion.BlockTypeToggle, Toggle: &notion.ToggleBlock{RichText: text("More <info>")}}
+	blocks := []notion.Block{withChildren(&toggle, notion.NewParagraphBlock(text("Hidden")))}
+
+	expected := "<details>\n<summary>More &lt;info&gt;</summary>\n\nHidden\n\n</details>\n"
+	if got := Render(blocks); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	table := withChildren(notion.NewTableBlock(2, true, false),
+		notion.NewTableRowBlock([][]notion.RichText{text("Name"), text("Value")}),
+		notion.NewTableRowBlock([][]notion.RichText{text("a|b"), text("1")}),
+	)
+
+	expected := "| Name | Value |\n| --- | --- |\n| a\\|b | 1 |\n"
+	if got := Render([]notion.Block{table}); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	commonMark := NewRenderer(&Options{Flavor: CommonMark}).Render([]notion.Block{table})
+	expected = "<table>\n<tr><th>Name</th><th>Value</th></tr>\n<tr><td>a|b</td><td>1</td></tr>\n</table>\n"
+	if commonMark != expected {
+		t.Errorf("Expected %q, got %q", expected, commonMark)
+	}
+}
+
+func TestRenderEscapesText(t *testing.T) {
+	blocks := []notion.Block{
+		*notion.NewParagraphBlock(text("# not a *heading*")),
+		*notion.NewParagraphBlock(text("1. not a list")),
+	}
+
+	expected := "\\# not a \\*heading\\*\n\n1\\. not a list\n"
+	if got := Render(blocks); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestRenderHooks(t *testing.T) {
+	blocks := []notion.Block{
+		{Type: notion.BlockTypeChildPage, ChildPage: &notion.ChildPageBlock{Title: "Sub page"}},
+		{Type: notion.BlockTypeBreadcrumb, Breadcrumb: map[string]interface{}{}},
+		*notion.NewDividerBlock(),
+	}
+
+	r := NewRenderer(&Options{
+		Hooks: map[string]BlockHook{
+			notion.BlockTypeDivider: func(r *Renderer, block *notion.Block) string {
+				return "***"
+			},
+		},
+		Unsupported: func(r *Renderer, block *notion.Block) string {
+			if block.Type == notion.BlockTypeChildPage {
+				return "[" + block.ChildPage.Title + "](sub-page.md)"
+			}
+			return ""
+		},
+	})
+
+	expected := "[Sub page](sub-page.md)\n\n***\n"
+	if got := r.Render(blocks); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if got := Render(blocks[:2]); got != "" {
+		t.Errorf("Expected unsupported blocks to be dropped, got %q", got)
+	}
+}
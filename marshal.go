@@ -0,0 +1,758 @@
+package notion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const propertyTypeID = "id"
+
+// propertyField describes a struct field mapped to a page property
+type propertyField struct {
+	field     reflect.StructField
+	name      string
+	propType  string
+	omitEmpty bool
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateType     = reflect.TypeOf(Date{})
+	richTextType = reflect.TypeOf([]RichText{})
+)
+
+// MarshalProperties converts a struct into page property values suitable for
+// CreatePageRequest and UpdatePageRequest. Fields are mapped to properties
+// with the "notion" struct tag:
+//
+//	type Task struct {
+//		ID       string    `notion:",id"`
+//		Name     string    `notion:"Name,title"`
+//		Priority *float64  `notion:"Priority,number"`
+//		Tags     []string  `notion:"Tags,multi_select"`
+//		Due      time.Time `notion:"Due,date,omitempty"`
+//		Done     bool      `notion:"Done,checkbox"`
+//		Blockers []string  `notion:"Blocked by,relation"`
+//	}
+//
+// The first tag element is the property name and the second one of the
+// PropertyType constants. The "id" type maps the page ID and is ignored when
+// marshalling, as are the computed formula, rollup, created_* and
+// last_edited_* types. The "omitempty" option skips zero values; without it
+// nil pointers and zero times are sent as null, clearing the property. Fields
+// without a tag, or tagged "-", are ignored. Supported Go types are:
+//
+//	title, rich_text            string, []RichText
+//	number                      any int or float kind, or a pointer to one
+//	select, status              string, *string
+//	multi_select                []string (option names)
+//	date                        time.Time, Date, string, or a pointer to one
+//	people                      []string (user IDs)
+//	files                       []string (URLs)
+//	checkbox                    bool, *bool
+//	url, email, phone_number    string, *string
+//	relation                    []string (page IDs)
+//	formula                     string, bool, numbers or time.Time
+//	rollup                      numbers, time.Time or []string
+//	created_time                time.Time, string
+//	last_edited_time            time.Time, string
+//	created_by, last_edited_by  string (user ID)
+func MarshalProperties(v interface{}) (map[string]PageProperty, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("notion: cannot marshal nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notion: cannot marshal %T, expected a struct", v)
+	}
+
+	fields, err := propertyFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]PageProperty, len(fields))
+	for _, f := range fields {
+		if isReadOnlyPropertyType(f.propType) {
+			continue
+		}
+		fv := rv.FieldByIndex(f.field.Index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		prop, ok, err := marshalProperty(f, fv)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			properties[f.name] = prop
+		}
+	}
+
+	return properties, nil
+}
+
+// UnmarshalProperties copies the property values of a page into a struct
+// tagged as described for MarshalProperties. It returns an error if a tagged
+// property is missing from the page or has a different type.
+func UnmarshalProperties(page *Page, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("notion: cannot unmarshal into %T, expected a non-nil struct pointer", v)
+	}
+	if page == nil {
+		return fmt.Errorf("notion: cannot unmarshal nil page")
+	}
+	rv = rv.Elem()
+
+	fields, err := propertyFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.field.Index)
+		if f.propType == propertyTypeID {
+			if err := setString(fv, page.ID); err != nil {
+				return f.errorf("%v", err)
+			}
+			continue
+		}
+
+		prop, ok := page.Properties[f.name]
+		if !ok {
+			return f.errorf("property not found on page %s", page.ID)
+		}
+		if prop.Type != f.propType {
+			return f.errorf("page property has type %s", prop.Type)
+		}
+		if err := unmarshalProperty(f, prop, fv); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// propertyFields returns the tagged fields of a struct type
+func propertyFields(t reflect.Type) ([]propertyField, error) {
+	var fields []propertyField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("notion")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		f := propertyField{field: sf, name: parts[0]}
+		if len(parts) > 1 {
+			f.propType = parts[1]
+		}
+		for _, opt := range parts[min(len(parts), 2):] {
+			switch opt {
+			case "omitempty":
+				f.omitEmpty = true
+			default:
+				return nil, f.errorf("unknown tag option %q", opt)
+			}
+		}
+
+		if f.propType == "" {
+			return nil, f.errorf("missing property type in tag %q", tag)
+		}
+		if !isPropertyType(f.propType) && f.propType != propertyTypeID {
+			return nil, f.errorf("unknown property type %q", f.propType)
+		}
+		if f.name == "" && f.propType != propertyTypeID {
+			return nil, f.errorf("missing property name in tag %q", tag)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (f propertyField) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("notion: field %s (%s) for property %q of type %s: %s",
+		f.field.Name, f.field.Type, f.name, f.propType, fmt.Sprintf(format, args...))
+}
+
+// isPropertyType reports whether t is one of the PropertyType constants
+func isPropertyType(t string) bool {
+	switch t {
+	case PropertyTypeTitle, PropertyTypeRichText, PropertyTypeNumber, PropertyTypeSelect,
+		PropertyTypeMultiSelect, PropertyTypeDate, PropertyTypePeople, PropertyTypeFiles,
+		PropertyTypeCheckbox, PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber,
+		PropertyTypeFormula, PropertyTypeRelation, PropertyTypeRollup, PropertyTypeCreatedTime,
+		PropertyTypeCreatedBy, PropertyTypeLastEditedTime, PropertyTypeLastEditedBy, PropertyTypeStatus,
+		PropertyTypeUniqueID:
+		return true
+	}
+	return false
+}
+
+// isReadOnlyPropertyType reports whether values of type t are computed by Notion
+func isReadOnlyPropertyType(t string) bool {
+	switch t {
+	case propertyTypeID, PropertyTypeFormula, PropertyTypeRollup, PropertyTypeCreatedTime,
+		PropertyTypeCreatedBy, PropertyTypeLastEditedTime, PropertyTypeLastEditedBy, PropertyTypeUniqueID:
+		return true
+	}
+	return false
+}
+
+// marshalProperty converts a field value into a page property. Nil pointers,
+// zero times and empty select, status and date strings produce an empty
+// property, which clears the value.
+func marshalProperty(f propertyField, fv reflect.Value) (PageProperty, bool, error) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return NewEmptyProperty(f.propType), true, nil
+		}
+		fv = fv.Elem()
+	}
+	mismatch := func() (PageProperty, bool, error) {
+		return PageProperty{}, false, f.errorf("unsupported Go type")
+	}
+
+	switch f.propType {
+	case PropertyTypeTitle, PropertyTypeRichText:
+		var richText []RichText
+		switch {
+		case fv.Kind() == reflect.String:
+			richText = []RichText{NewText(fv.String())}
+		case fv.Type() == richTextType:
+			richText = fv.Interface().([]RichText)
+		default:
+			return mismatch()
+		}
+		if f.propType == PropertyTypeTitle {
+			return NewTitleProperty(richText), true, nil
+		}
+		return NewRichTextProperty(richText), true, nil
+
+	case PropertyTypeNumber:
+		n, ok := floatValue(fv)
+		if !ok {
+			return mismatch()
+		}
+		return NewNumberProperty(n), true, nil
+
+	case PropertyTypeSelect, PropertyTypeStatus:
+		if fv.Kind() != reflect.String {
+			return mismatch()
+		}
+		if fv.String() == "" {
+			return NewEmptyProperty(f.propType), true, nil
+		}
+		if f.propType == PropertyTypeStatus {
+			return PageProperty{Type: PropertyTypeStatus, Status: &StatusOption{Name: fv.String()}}, true, nil
+		}
+		return NewSelectProperty(SelectOption{Name: fv.String()}), true, nil
+
+	case PropertyTypeMultiSelect:
+		names, ok := stringsValue(fv)
+		if !ok {
+			return mismatch()
+		}
+		options := make([]SelectOption, 0, len(names))
+		for _, name := range names {
+			options = append(options, SelectOption{Name: name})
+		}
+		return NewMultiSelectProperty(options), true, nil
+
+	case PropertyTypeDate:
+		switch {
+		case fv.Type() == timeType:
+			t := fv.Interface().(time.Time)
+			if t.IsZero() {
+				return NewEmptyProperty(f.propType), true, nil
+			}
+			return NewDateProperty(Date{Start: formatNotionTime(t)}), true, nil
+		case fv.Type() == dateType:
+			date := fv.Interface().(Date)
+			if date.Start == "" {
+				return NewEmptyProperty(f.propType), true, nil
+			}
+			return NewDateProperty(date), true, nil
+		case fv.Kind() == reflect.String:
+			if fv.String() == "" {
+				return NewEmptyProperty(f.propType), true, nil
+			}
+			return NewDateProperty(Date{Start: fv.String()}), true, nil
+		}
+		return mismatch()
+
+	case PropertyTypePeople:
+		ids, ok := stringsValue(fv)
+		if !ok {
+			return mismatch()
+		}
+		people := make([]User, 0, len(ids))
+		for _, id := range ids {
+			people = append(people, User{Object: ObjectTypeUser, ID: id})
+		}
+		return NewPeopleProperty(people), true, nil
+
+	case PropertyTypeFiles:
+		urls, ok := stringsValue(fv)
+		if !ok {
+			return mismatch()
+		}
+		files := make([]PropertyFile, 0, len(urls))
+		for _, url := range urls {
+			files = append(files, NewExternalFile(url, url))
+		}
+		return NewFilesProperty(files), true, nil
+
+	case PropertyTypeCheckbox:
+		if fv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		return NewCheckboxProperty(fv.Bool()), true, nil
+
+	case PropertyTypeURL, PropertyTypeEmail, PropertyTypePhoneNumber:
+		if fv.Kind() != reflect.String {
+			return mismatch()
+		}
+		switch f.propType {
+		case PropertyTypeURL:
+			return NewURLProperty(fv.String()), true, nil
+		case PropertyTypeEmail:
+			return NewEmailProperty(fv.String()), true, nil
+		}
+		return NewPhoneNumberProperty(fv.String()), true, nil
+
+	case PropertyTypeRelation:
+		ids, ok := stringsValue(fv)
+		if !ok {
+			return mismatch()
+		}
+		relations := make([]Relation, 0, len(ids))
+		for _, id := range ids {
+			relations = append(relations, Relation{ID: id})
+		}
+		return NewRelationProperty(relations), true, nil
+	}
+
+	return mismatch()
+}
+
+// unmarshalProperty stores a page property value into a field
+func unmarshalProperty(f propertyField, prop PageProperty, fv reflect.Value) error {
+	var err error
+	switch f.propType {
+	case PropertyTypeTitle:
+		err = setRichText(fv, prop.Title)
+	case PropertyTypeRichText:
+		err = setRichText(fv, prop.RichText)
+	case PropertyTypeNumber:
+		err = setFloat(fv, prop.Number)
+	case PropertyTypeSelect:
+		err = setOptionalString(fv, selectName(prop.Select))
+	case PropertyTypeStatus:
+		var name *string
+		if prop.Status != nil {
+			name = &prop.Status.Name
+		}
+		err = setOptionalString(fv, name)
+	case PropertyTypeMultiSelect:
+		names := make([]string, 0, len(prop.MultiSelect))
+		for _, option := range prop.MultiSelect {
+			names = append(names, option.Name)
+		}
+		err = setStrings(fv, names)
+	case PropertyTypeDate:
+		err = setDate(fv, prop.Date)
+	case PropertyTypePeople:
+		ids := make([]string, 0, len(prop.People))
+		for _, user := range prop.People {
+			ids = append(ids, user.ID)
+		}
+		err = setStrings(fv, ids)
+	case PropertyTypeFiles:
+		urls := make([]string, 0, len(prop.Files))
+		for _, file := range prop.Files {
+			urls = append(urls, file.URL())
+		}
+		err = setStrings(fv, urls)
+	case PropertyTypeCheckbox:
+		err = setBool(fv, prop.Checkbox)
+	case PropertyTypeURL:
+		err = setString(fv, prop.URL)
+	case PropertyTypeEmail:
+		err = setString(fv, prop.Email)
+	case PropertyTypePhoneNumber:
+		err = setString(fv, prop.PhoneNumber)
+	case PropertyTypeRelation:
+		ids := make([]string, 0, len(prop.Relation))
+		for _, relation := range prop.Relation {
+			ids = append(ids, relation.ID)
+		}
+		err = setStrings(fv, ids)
+	case PropertyTypeFormula:
+		err = setFormula(fv, prop.Formula)
+	case PropertyTypeRollup:
+		err = setRollup(fv, prop.Rollup)
+	case PropertyTypeCreatedTime:
+		err = setTimestamp(fv, prop.CreatedTime)
+	case PropertyTypeLastEditedTime:
+		err = setTimestamp(fv, prop.LastEditedTime)
+	case PropertyTypeCreatedBy:
+		err = setString(fv, userID(prop.CreatedBy))
+	case PropertyTypeLastEditedBy:
+		err = setString(fv, userID(prop.LastEditedBy))
+	case PropertyTypeUniqueID:
+		err = setUniqueID(fv, prop.UniqueID)
+	}
+	if err != nil {
+		return f.errorf("%v", err)
+	}
+	return nil
+}
+
+func selectName(option *SelectOption) *string {
+	if option == nil {
+		return nil
+	}
+	return &option.Name
+}
+
+func userID(user *User) string {
+	if user == nil {
+		return ""
+	}
+	return user.ID
+}
+
+// floatValue returns the numeric value of an int, uint or float field
+func floatValue(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	}
+	return 0, false
+}
+
+// stringsValue returns the value of a []string field
+func stringsValue(fv reflect.Value) ([]string, bool) {
+	if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.String {
+		return nil, false
+	}
+	values := make([]string, fv.Len())
+	for i := range values {
+		values[i] = fv.Index(i).String()
+	}
+	return values, true
+}
+
+func errUnsupportedType(fv reflect.Value) error {
+	return fmt.Errorf("unsupported Go type %s", fv.Type())
+}
+
+func setString(fv reflect.Value, s string) error {
+	return setOptionalString(fv, &s)
+}
+
+// setOptionalString stores s into a string or *string field; nil clears it
+func setOptionalString(fv reflect.Value, s *string) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		if s == nil {
+			fv.SetString("")
+		} else {
+			fv.SetString(*s)
+		}
+	case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.String:
+		if s == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+		} else {
+			p := reflect.New(fv.Type().Elem())
+			p.Elem().SetString(*s)
+			fv.Set(p)
+		}
+	default:
+		return errUnsupportedType(fv)
+	}
+	return nil
+}
+
+func setStrings(fv reflect.Value, values []string) error {
+	if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() != reflect.String {
+		return errUnsupportedType(fv)
+	}
+	slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+	for i, value := range values {
+		slice.Index(i).SetString(value)
+	}
+	fv.Set(slice)
+	return nil
+}
+
+func setBool(fv reflect.Value, b bool) error {
+	switch {
+	case fv.Kind() == reflect.Bool:
+		fv.SetBool(b)
+	case fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Bool:
+		p := reflect.New(fv.Type().Elem())
+		p.Elem().SetBool(b)
+		fv.Set(p)
+	default:
+		return errUnsupportedType(fv)
+	}
+	return nil
+}
+
+func setRichText(fv reflect.Value, richText []RichText) error {
+	switch {
+	case fv.Kind() == reflect.String:
+		fv.SetString(PlainText(richText))
+	case fv.Type() == richTextType:
+		fv.Set(reflect.ValueOf(richText))
+	default:
+		return errUnsupportedType(fv)
+	}
+	return nil
+}
+
+// setFloat stores n into a numeric or pointer to numeric field; nil stores zero or nil
+func setFloat(fv reflect.Value, n *float64) error {
+	if fv.Kind() == reflect.Ptr {
+		if _, ok := floatValue(reflect.Zero(fv.Type().Elem())); !ok {
+			return errUnsupportedType(fv)
+		}
+		if n == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		p := reflect.New(fv.Type().Elem())
+		if err := setFloat(p.Elem(), n); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+
+	var value float64
+	if n != nil {
+		value = *n
+	}
+	switch fv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		fv.SetFloat(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(int64(value))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(value))
+	default:
+		return errUnsupportedType(fv)
+	}
+	return nil
+}
+
+// setDate stores a date into a time.Time, Date or string field, or a pointer to one
+func setDate(fv reflect.Value, date *Date) error {
+	if fv.Kind() == reflect.Ptr {
+		elem := fv.Type().Elem()
+		if elem != timeType && elem != dateType && elem.Kind() != reflect.String {
+			return errUnsupportedType(fv)
+		}
+		if date == nil {
+			fv.Set(reflect.Zero(fv.Type()))
+			return nil
+		}
+		p := reflect.New(elem)
+		if err := setDate(p.Elem(), date); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+
+	switch {
+	case fv.Type() == timeType:
+		var t time.Time
+		if date != nil {
+			var err error
+			if t, err = parseNotionTime(date.Start); err != nil {
+				return err
+			}
+		}
+		fv.Set(reflect.ValueOf(t))
+	case fv.Type() == dateType:
+		var d Date
+		if date != nil {
+			d = *date
+		}
+		fv.Set(reflect.ValueOf(d))
+	case fv.Kind() == reflect.String:
+		if date == nil {
+			fv.SetString("")
+		} else {
+			fv.SetString(date.Start)
+		}
+	default:
+		return errUnsupportedType(fv)
+	}
+	return nil
+}
+
+// setTimestamp stores an ISO 8601 timestamp into a time.Time or string field
+func setTimestamp(fv reflect.Value, timestamp string) error {
+	if fv.Type() == timeType {
+		var t time.Time
+		if timestamp != "" {
+			var err error
+			if t, err = parseNotionTime(timestamp); err != nil {
+				return err
+			}
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil
+	}
+	return setString(fv, timestamp)
+}
+
+// setFormula stores a formula result according to the result type
+// setUniqueID stores a unique ID into a string field as PREFIX-123, or into
+// a numeric field as its number
+func setUniqueID(fv reflect.Value, id *UniqueID) error {
+	if fv.Kind() == reflect.String || (fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.String) {
+		if id == nil {
+			return setOptionalString(fv, nil)
+		}
+		return setString(fv, id.String())
+	}
+	if id == nil {
+		return setFloat(fv, nil)
+	}
+	n := float64(id.Number)
+	return setFloat(fv, &n)
+}
+
+func setFormula(fv reflect.Value, formula *Formula) error {
+	if formula == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	switch formula.Type {
+	case "string":
+		return setString(fv, formula.String)
+	case "number":
+		return setFloat(fv, formula.Number)
+	case "boolean":
+		return setBool(fv, formula.Boolean != nil && *formula.Boolean)
+	case "date":
+		return setDate(fv, formula.Date)
+	}
+	return fmt.Errorf("unsupported formula type %q", formula.Type)
+}
+
+// setRollup stores a number or date rollup result, or the plain text of
+// array values into a []string field
+func setRollup(fv reflect.Value, rollup *Rollup) error {
+	if rollup == nil {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	switch rollup.Type {
+	case "number":
+		return setFloat(fv, rollup.Number)
+	case "date":
+		return setDate(fv, rollup.Date)
+	case "array":
+		values := make([]string, 0, len(rollup.Array))
+		for _, value := range rollup.Array {
+			values = append(values, rollupValueText(value))
+		}
+		return setStrings(fv, values)
+	}
+	return fmt.Errorf("unsupported rollup type %q", rollup.Type)
+}
+
+// rollupValueText returns a textual representation of a rollup array value
+func rollupValueText(value RollupValue) string {
+	switch value.Type {
+	case PropertyTypeTitle:
+		return PlainText(value.Title)
+	case PropertyTypeRichText:
+		return PlainText(value.RichText)
+	case PropertyTypeNumber:
+		if value.Number != nil {
+			return fmt.Sprint(*value.Number)
+		}
+	case PropertyTypeSelect:
+		if value.Select != nil {
+			return value.Select.Name
+		}
+	case PropertyTypeDate:
+		if value.Date != nil {
+			return value.Date.Start
+		}
+	case PropertyTypeURL:
+		return value.URL
+	case PropertyTypeEmail:
+		return value.Email
+	case PropertyTypePhoneNumber:
+		return value.PhoneNumber
+	case PropertyTypeCheckbox:
+		return fmt.Sprint(value.Checkbox)
+	case PropertyTypeFormula:
+		if value.Formula != nil && value.Formula.Type == "string" {
+			return value.Formula.String
+		}
+	}
+	return ""
+}
+
+// formatNotionTime formats t as a Notion date, omitting the time of day when
+// t is midnight UTC
+func formatNotionTime(t time.Time) string {
+	if t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseNotionTime parses a date or date-time as returned by the Notion API
+func parseNotionTime(s string) (time.Time, error) {
+	t, _, err := parseNotionDate(s, "")
+	return t, err
+}
+
+// notionDateLayouts are the layouts of dates accepted and returned by Notion
+var notionDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z07:00",
+	"2006-01-02T15:04:05.000",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseNotionDate parses a date or date-time, reporting whether it has no
+// time. Values without an offset are in the IANA time zone timeZone, or UTC
+// when it is empty.
+func parseNotionDate(s, timeZone string) (t time.Time, dateOnly bool, err error) {
+	loc := time.UTC
+	if timeZone != "" {
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+		}
+	}
+	for _, layout := range notionDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, layout == "2006-01-02", nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,275 @@
+package notion
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testTask struct {
+	ID        string    `notion:",id"`
+	Name      string    `notion:"Name,title"`
+	Notes     string    `notion:"Notes,rich_text"`
+	Priority  *float64  `notion:"Priority,number"`
+	Estimate  int       `notion:"Estimate,number"`
+	Stage     string    `notion:"Stage,select"`
+	State     string    `notion:"State,status"`
+	Tags      []string  `notion:"Tags,multi_select"`
+	Due       time.Time `notion:"Due,date,omitempty"`
+	Owners    []string  `notion:"Owners,people"`
+	Done      bool      `notion:"Done,checkbox"`
+	Link      string    `notion:"Link,url"`
+	Email     string    `notion:"Email,email"`
+	Phone     string    `notion:"Phone,phone_number"`
+	Blockers  []string  `notion:"Blocked by,relation"`
+	Score     float64   `notion:"Score,formula"`
+	Created   time.Time `notion:"Created,created_time"`
+	CreatedBy string    `notion:"Created by,created_by"`
+	Ignored   string
+}
+
+func TestMarshalProperties(t *testing.T) {
+	priority := 2.0
+	task := testTask{
+		Name:     "Write docs",
+		Priority: &priority,
+		Estimate: 3,
+		Stage:    "Backlog",
+		State:    "In progress",
+		Tags:     []string{"docs", "go"},
+		Due:      time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Owners:   []string{"user-1"},
+		Done:     true,
+		Link:     "https://example.com",
+		Blockers: []string{"page-2"},
+		Score:    10,
+	}
+
+	props, err := MarshalProperties(task)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if props["Name"].Type != PropertyTypeTitle || PlainText(props["Name"].Title) != "Write docs" {
+		t.Errorf("Expected title 'Write docs', got %+v", props["Name"])
+	}
+	if *props["Priority"].Number != 2 || *props["Estimate"].Number != 3 {
+		t.Errorf("Expected numbers 2 and 3, got %v and %v", *props["Priority"].Number, *props["Estimate"].Number)
+	}
+	if props["Stage"].Select.Name != "Backlog" || props["State"].Status.Name != "In progress" {
+		t.Errorf("Expected select and status names, got %+v and %+v", props["Stage"], props["State"])
+	}
+	if len(props["Tags"].MultiSelect) != 2 || props["Tags"].MultiSelect[1].Name != "go" {
+		t.Errorf("Expected tags docs and go, got %+v", props["Tags"].MultiSelect)
+	}
+	if props["Due"].Date.Start != "2024-03-01" {
+		t.Errorf("Expected date-only start '2024-03-01', got '%s'", props["Due"].Date.Start)
+	}
+	if props["Owners"].People[0].ID != "user-1" || props["Blocked by"].Relation[0].ID != "page-2" {
+		t.Errorf("Expected people and relation IDs, got %+v and %+v", props["Owners"], props["Blocked by"])
+	}
+	if !props["Done"].Checkbox || props["Link"].URL != "https://example.com" {
+		t.Errorf("Expected checkbox and URL, got %+v and %+v", props["Done"], props["Link"])
+	}
+	for _, name := range []string{"Score", "Created", "Created by", "Ignored"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("Expected read-only or untagged field %q to be skipped", name)
+		}
+	}
+}
+
+func TestMarshalFilesProperty(t *testing.T) {
+	type attachment struct {
+		Files []string `notion:"Files,files"`
+	}
+	props, err := MarshalProperties(attachment{Files: []string{"https://example.com/a.pdf"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, err := json.Marshal(props["Files"])
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"files":[{"name":"https://example.com/a.pdf","type":"external","external":{"url":"https://example.com/a.pdf"}}],"type":"files"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var hosted PageProperty
+	if err := json.Unmarshal([]byte(`{"type":"files","files":[{"name":"a.pdf","type":"file","file":{"url":"https://files.example.com/a.pdf"}}]}`), &hosted); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	page := Page{Properties: map[string]PageProperty{"Files": hosted}}
+	var got attachment
+	if err := UnmarshalProperties(&page, &got); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(got.Files) != 1 || got.Files[0] != "https://files.example.com/a.pdf" {
+		t.Errorf("Expected the hosted file URL, got %v", got.Files)
+	}
+}
+
+func TestMarshalPropertiesEmptyValues(t *testing.T) {
+	props, err := MarshalProperties(&testTask{Name: "x"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := props["Due"]; ok {
+		t.Error("Expected empty date to be omitted")
+	}
+	if prop, ok := props["Priority"]; !ok || prop.Type != PropertyTypeNumber || prop.Number != nil {
+		t.Errorf("Expected nil number to clear the property, got %+v", prop)
+	}
+
+	type unset struct {
+		Stage string `notion:"Stage,select"`
+		State string `notion:"State,status"`
+		Due   string `notion:"Due,date"`
+		Range Date   `notion:"Range,date"`
+	}
+	props, err = MarshalProperties(unset{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[string]string{
+		"Stage": `{"select":null,"type":"select"}`,
+		"State": `{"status":null,"type":"status"}`,
+		"Due":   `{"date":null,"type":"date"}`,
+		"Range": `{"date":null,"type":"date"}`,
+	}
+	for name, want := range expected {
+		data, err := json.Marshal(props[name])
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("Expected empty %s to be sent as %s, got %s", name, want, data)
+		}
+	}
+}
+
+func TestMarshalPropertiesTypeMismatch(t *testing.T) {
+	type bad struct {
+		Due int `notion:"Due,date"`
+	}
+	_, err := MarshalProperties(bad{})
+	if err == nil || !strings.Contains(err.Error(), `field Due (int) for property "Due" of type date`) {
+		t.Errorf("Expected a descriptive error, got %v", err)
+	}
+
+	type unknown struct {
+		Name string `notion:"Name,text"`
+	}
+	if _, err := MarshalProperties(unknown{}); err == nil || !strings.Contains(err.Error(), `unknown property type "text"`) {
+		t.Errorf("Expected unknown property type error, got %v", err)
+	}
+}
+
+const taskPageJSON = `{
+	"object": "page",
+	"id": "page-1",
+	"properties": {
+		"Name": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Write "}, {"type": "text", "plain_text": "docs"}]},
+		"Notes": {"id": "n", "type": "rich_text", "rich_text": []},
+		"Priority": {"id": "p", "type": "number", "number": 2},
+		"Estimate": {"id": "e", "type": "number", "number": null},
+		"Stage": {"id": "s", "type": "select", "select": {"name": "Backlog"}},
+		"State": {"id": "st", "type": "status", "status": {"name": "Done"}},
+		"Tags": {"id": "t", "type": "multi_select", "multi_select": [{"name": "docs"}, {"name": "go"}]},
+		"Due": {"id": "d", "type": "date", "date": {"start": "2024-03-01T10:30:00.000+02:00"}},
+		"Owners": {"id": "o", "type": "people", "people": [{"object": "user", "id": "user-1"}]},
+		"Done": {"id": "c", "type": "checkbox", "checkbox": true},
+		"Link": {"id": "u", "type": "url", "url": "https://example.com"},
+		"Email": {"id": "em", "type": "email", "email": null},
+		"Phone": {"id": "ph", "type": "phone_number", "phone_number": "+1"},
+		"Blocked by": {"id": "r", "type": "relation", "relation": [{"id": "page-2"}]},
+		"Score": {"id": "f", "type": "formula", "formula": {"type": "number", "number": 10}},
+		"Created": {"id": "ct", "type": "created_time", "created_time": "2024-01-02T03:04:00.000Z"},
+		"Created by": {"id": "cb", "type": "created_by", "created_by": {"object": "user", "id": "user-2"}}
+	}
+}`
+
+func TestUnmarshalProperties(t *testing.T) {
+	var page Page
+	if err := json.Unmarshal([]byte(taskPageJSON), &page); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var task testTask
+	if err := UnmarshalProperties(&page, &task); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if task.ID != "page-1" || task.Name != "Write docs" {
+		t.Errorf("Expected ID and name, got '%s' and '%s'", task.ID, task.Name)
+	}
+	if task.Priority == nil || *task.Priority != 2 || task.Estimate != 0 {
+		t.Errorf("Expected priority 2 and estimate 0, got %v and %d", task.Priority, task.Estimate)
+	}
+	if task.Stage != "Backlog" || task.State != "Done" {
+		t.Errorf("Expected select and status, got '%s' and '%s'", task.Stage, task.State)
+	}
+	if strings.Join(task.Tags, ",") != "docs,go" {
+		t.Errorf("Expected tags docs,go, got %v", task.Tags)
+	}
+	if !task.Due.Equal(time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected due 2024-03-01T08:30Z, got %v", task.Due)
+	}
+	if task.Owners[0] != "user-1" || task.Blockers[0] != "page-2" || task.CreatedBy != "user-2" {
+		t.Errorf("Expected user and page IDs, got %v, %v and '%s'", task.Owners, task.Blockers, task.CreatedBy)
+	}
+	if !task.Done || task.Link != "https://example.com" || task.Phone != "+1" || task.Score != 10 {
+		t.Errorf("Expected checkbox, url, phone and formula values, got %+v", task)
+	}
+	if task.Created.Year() != 2024 {
+		t.Errorf("Expected created time in 2024, got %v", task.Created)
+	}
+}
+
+func TestUnmarshalUniqueID(t *testing.T) {
+	page := Page{Properties: map[string]PageProperty{
+		"Key":    {Type: PropertyTypeUniqueID, UniqueID: &UniqueID{Prefix: "TASK", Number: 12}},
+		"Number": {Type: PropertyTypeUniqueID, UniqueID: &UniqueID{Number: 7}},
+	}}
+	var ticket struct {
+		Key    string `notion:"Key,unique_id"`
+		KeyNum int    `notion:"Key,unique_id"`
+		Number string `notion:"Number,unique_id"`
+	}
+	if err := UnmarshalProperties(&page, &ticket); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ticket.Key != "TASK-12" || ticket.KeyNum != 12 || ticket.Number != "7" {
+		t.Errorf("Expected TASK-12, 12 and 7, got %+v", ticket)
+	}
+
+	props, err := MarshalProperties(ticket)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(props) != 0 {
+		t.Errorf("Expected read-only unique IDs to be skipped, got %+v", props)
+	}
+}
+
+func TestUnmarshalPropertiesSchemaMismatch(t *testing.T) {
+	var page Page
+	json.Unmarshal([]byte(taskPageJSON), &page)
+
+	var wrongType struct {
+		Name string `notion:"Priority,rich_text"`
+	}
+	err := UnmarshalProperties(&page, &wrongType)
+	if err == nil || !strings.Contains(err.Error(), "page property has type number") {
+		t.Errorf("Expected type mismatch error, got %v", err)
+	}
+
+	var missing struct {
+		Name string `notion:"Missing,title"`
+	}
+	err = UnmarshalProperties(&page, &missing)
+	if err == nil || !strings.Contains(err.Error(), "property not found") {
+		t.Errorf("Expected missing property error, got %v", err)
+	}
+}
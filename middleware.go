@@ -0,0 +1,63 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request describes a single Notion API call as seen by middleware
+type Request struct {
+	// Method is the HTTP method, e.g. "GET" or "PATCH"
+	Method string
+	// Path is the API path relative to the base URL, including any query string
+	Path string
+	// Body is the request body before JSON encoding, or nil
+	Body interface{}
+	// Header holds the headers sent with the request, including
+	// Authorization and Notion-Version
+	Header http.Header
+}
+
+// Handler sends a request to the Notion API and returns the raw HTTP
+// response. Responses with an error status are returned without an error;
+// the client decodes them after the whole chain has run.
+type Handler func(ctx context.Context, req *Request) (*http.Response, error)
+
+// Middleware wraps a Handler to intercept every API call made by a Client
+type Middleware func(next Handler) Handler
+
+// WithMiddleware registers middleware around every API call. Middleware runs
+// in the order given, with the first one outermost. User middleware wraps the
+// built-in retry and rate limiting middleware, so it observes each logical
+// call once; register RetryMiddleware or RateLimitMiddleware yourself instead
+// of using WithRetryPolicy or WithRateLimiter to control their position.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// Chain combines several middleware into one, with the first one outermost
+func Chain(middleware ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](next)
+		}
+		return next
+	}
+}
+
+// buildHandler assembles the client's middleware chain around the transport
+func (c *Client) buildHandler() Handler {
+	middleware := append([]Middleware(nil), c.middleware...)
+	if c.validateRequests {
+		middleware = append(middleware, ValidationMiddleware())
+	}
+	if c.retryPolicy != nil {
+		middleware = append(middleware, RetryMiddleware(c.retryPolicy))
+	}
+	if c.rateLimiter != nil {
+		middleware = append(middleware, RateLimitMiddleware(c.rateLimiter))
+	}
+	return Chain(middleware...)(c.do)
+}
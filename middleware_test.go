@@ -0,0 +1,93 @@
+package notion
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrderAndMutation(t *testing.T) {
+	var gotAuth, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Write([]byte(`{"object":"page","id":"page-id"}`))
+	}))
+	defer server.Close()
+
+	var events []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*http.Response, error) {
+				events = append(events, name+" "+req.Method+" "+req.Path)
+				resp, err := next(ctx, req)
+				if err == nil {
+					events = append(events, name+" "+resp.Status)
+				}
+				return resp, err
+			}
+		}
+	}
+	swapAuth := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer other")
+			if body, ok := req.Body.(*UpdatePageRequest); ok {
+				archived := true
+				body.Archived = &archived
+			}
+			return next(ctx, req)
+		}
+	}
+
+	client := NewClient("key", WithBaseURL(server.URL), WithMiddleware(record("outer"), record("inner"), swapAuth))
+	if _, err := client.UpdatePage(context.Background(), "page-id", &UpdatePageRequest{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		"outer PATCH /pages/page-id",
+		"inner PATCH /pages/page-id",
+		"inner 200 OK",
+		"outer 200 OK",
+	}
+	if strings.Join(events, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+	if gotAuth != "Bearer other" {
+		t.Errorf("Expected swapped authorization header, got '%s'", gotAuth)
+	}
+	if !strings.Contains(gotBody, `"archived":true`) {
+		t.Errorf("Expected mutated body to be sent, got '%s'", gotBody)
+	}
+}
+
+func TestMiddlewareSeesErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object":"error","status":404,"code":"object_not_found","message":"missing"}`))
+	}))
+	defer server.Close()
+
+	var status int
+	observe := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			resp, err := next(ctx, req)
+			if err == nil {
+				status = resp.StatusCode
+			}
+			return resp, err
+		}
+	}
+
+	client := NewClient("key", WithBaseURL(server.URL), WithMiddleware(observe))
+	if _, err := client.GetPage(context.Background(), "page-id"); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if status != http.StatusNotFound {
+		t.Errorf("Expected middleware to see status 404, got %d", status)
+	}
+}
@@ -0,0 +1,290 @@
+package notiontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// maxNesting is the number of nested children levels accepted in one request
+const maxNesting = 2
+
+// AddBlocks appends blocks and their children to a page or block directly,
+// without Notion's limits on request size and nesting
+func (s *Server) AddBlocks(parentID string, blocks ...notion.Block) ([]notion.Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parentID = normalizeID(parentID)
+	if !s.exists(parentID) {
+		return nil, notFound("block", parentID)
+	}
+	return s.insertBlocks(parentID, clone(blocks), -1), nil
+}
+
+// Children returns copies of the children of a page or block, with their own
+// children filled in
+func (s *Server) Children(parentID string) []notion.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree(normalizeID(parentID))
+}
+
+// tree returns the live children of a block with their descendants
+func (s *Server) tree(parentID string) []notion.Block {
+	var blocks []notion.Block
+	for _, id := range s.children[parentID] {
+		block := s.block(id)
+		if block.Archived {
+			continue
+		}
+		if children := s.tree(id); len(children) > 0 {
+			if block.Type == notion.BlockTypeChildPage {
+				block.ChildPage.Children = children
+			} else {
+				block.SetChildren(children)
+			}
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// exists reports whether id is a page or block that can hold children
+func (s *Server) exists(id string) bool {
+	if _, ok := s.pages[id]; ok {
+		return true
+	}
+	_, ok := s.blocks[id]
+	return ok
+}
+
+// archived reports whether the page or block with the given ID is archived
+func (s *Server) archived(id string) bool {
+	if page, ok := s.pages[id]; ok {
+		return page.Archived
+	}
+	return s.blocks[id].Archived
+}
+
+// block returns a copy of a stored block as the API returns it
+func (s *Server) block(id string) notion.Block {
+	block := clone(*s.blocks[id])
+	for _, child := range s.children[id] {
+		if !s.blocks[child].Archived {
+			block.HasChildren = true
+			break
+		}
+	}
+	return block
+}
+
+// addChildBlock stores a block created as a side effect, such as the
+// child_page block of a new page
+func (s *Server) addChildBlock(parentID string, block *notion.Block) {
+	block.Object = notion.ObjectTypeBlock
+	block.Parent = s.blockParent(parentID)
+	block.CreatedTime = s.timestamp()
+	block.CreatedBy = s.botRef()
+	block.LastEditedTime = block.CreatedTime
+	block.LastEditedBy = s.botRef()
+	s.blocks[block.ID] = block
+	s.children[parentID] = append(s.children[parentID], block.ID)
+}
+
+func (s *Server) blockParent(parentID string) *notion.Parent {
+	if _, ok := s.pages[parentID]; ok {
+		return &notion.Parent{Type: "page_id", PageID: parentID}
+	}
+	return &notion.Parent{Type: "block_id", BlockID: parentID}
+}
+
+// validateBlocks checks the children of a request against Notion's limits
+// on the number of children and nesting
+func validateBlocks(path string, blocks []notion.Block, level int) *apiError {
+	if len(blocks) > maxPageSize {
+		return validationError("body failed validation: %s.length should be ≤ `%d`, instead was `%d`.", path, maxPageSize, len(blocks))
+	}
+	for i, block := range blocks {
+		blockPath := path + "[" + strconv.Itoa(i) + "]"
+		switch block.Type {
+		case "":
+			return validationError("body failed validation: %s.type should be defined, instead was `undefined`.", blockPath)
+		case notion.BlockTypeChildPage, notion.BlockTypeChildDatabase:
+			return validationError("body failed validation: %s.%s is not supported when appending children.", blockPath, block.Type)
+		}
+		children := block.Children()
+		if len(children) == 0 {
+			continue
+		}
+		childrenPath := blockPath + "." + block.Type + ".children"
+		if level >= maxNesting {
+			return validationError("body failed validation: %s should be not present, instead was `[...]`.", childrenPath)
+		}
+		if err := validateBlocks(childrenPath, children, level+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertBlocks stores blocks and their descendants under a parent, at index
+// at of its children or at the end when at is negative, and returns the
+// stored top level blocks
+func (s *Server) insertBlocks(parentID string, blocks []notion.Block, at int) []notion.Block {
+	ids := make([]string, len(blocks))
+	for i := range blocks {
+		block := blocks[i]
+		children := block.Children()
+		block.SetChildren(nil)
+		block.ID = newID()
+		block.Archived = false
+		block.HasChildren = false
+		s.addChildBlock(parentID, &block)
+		ids[i] = block.ID
+		s.insertBlocks(block.ID, children, -1)
+	}
+
+	// addChildBlock appended the new blocks, move them into place
+	existing := s.children[parentID]
+	existing = existing[:len(existing)-len(ids)]
+	if at < 0 || at > len(existing) {
+		at = len(existing)
+	}
+	s.children[parentID] = append(append(append([]string{}, existing[:at]...), ids...), existing[at:]...)
+
+	stored := make([]notion.Block, len(ids))
+	for i, id := range ids {
+		stored[i] = s.block(id)
+	}
+	return stored
+}
+
+func (s *Server) getBlock(id string) (interface{}, *apiError) {
+	if _, ok := s.blocks[id]; !ok {
+		return nil, notFound("block", id)
+	}
+	return s.block(id), nil
+}
+
+func (s *Server) updateBlock(r *http.Request, id string) (interface{}, *apiError) {
+	var fields map[string]json.RawMessage
+	if err := decode(r, &fields); err != nil {
+		return nil, err
+	}
+	stored, ok := s.blocks[id]
+	if !ok {
+		return nil, notFound("block", id)
+	}
+
+	var archived *bool
+	if raw, ok := fields["archived"]; ok {
+		if err := json.Unmarshal(raw, &archived); err != nil {
+			return nil, validationError("body failed validation: body.archived should be a boolean.")
+		}
+		delete(fields, "archived")
+	}
+	if stored.Archived && (archived == nil || *archived) {
+		return nil, validationError("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+
+	updated := clone(*stored)
+	for key, raw := range fields {
+		if key != updated.Type {
+			return nil, validationError("body failed validation: body.%s should be not present, the block is of type %s.", key, updated.Type)
+		}
+		var block map[string]json.RawMessage
+		data, _ := json.Marshal(updated)
+		json.Unmarshal(data, &block)
+		block[key] = raw
+		data, _ = json.Marshal(block)
+		updated = notion.Block{}
+		if err := json.Unmarshal(data, &updated); err != nil {
+			return nil, validationError("body failed validation: body.%s is invalid.", key)
+		}
+		updated.SetChildren(nil)
+	}
+	if archived != nil {
+		updated.Archived = *archived
+	}
+	updated.LastEditedTime = s.timestamp()
+	updated.LastEditedBy = s.botRef()
+	*stored = updated
+	if page, ok := s.pages[id]; ok {
+		page.Archived = updated.Archived
+	}
+	return s.block(id), nil
+}
+
+func (s *Server) deleteBlock(id string) (interface{}, *apiError) {
+	block, ok := s.blocks[id]
+	if !ok {
+		return nil, notFound("block", id)
+	}
+	block.Archived = true
+	block.LastEditedTime = s.timestamp()
+	if page, ok := s.pages[id]; ok {
+		page.Archived = true
+	}
+	if db, ok := s.databases[id]; ok {
+		db.Archived = true
+	}
+	return s.block(id), nil
+}
+
+func (s *Server) listChildren(r *http.Request, id string) (interface{}, *apiError) {
+	if !s.exists(id) {
+		return nil, notFound("block", id)
+	}
+	cursor, pageSize, err := queryPagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := []notion.Block{}
+	for _, child := range s.children[id] {
+		if !s.blocks[child].Archived {
+			blocks = append(blocks, s.block(child))
+		}
+	}
+	list, err := paginate(blocks, func(b notion.Block) string { return b.ID }, cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	list.Type = "block"
+	return list, nil
+}
+
+func (s *Server) appendChildren(r *http.Request, id string) (interface{}, *apiError) {
+	var req notion.AppendBlockChildrenRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if !s.exists(id) {
+		return nil, notFound("block", id)
+	}
+	if s.archived(id) {
+		return nil, validationError("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+	if err := validateBlocks("body.children", req.Children, 0); err != nil {
+		return nil, err
+	}
+
+	at := -1
+	if req.After != "" {
+		after := normalizeID(req.After)
+		for i, child := range s.children[id] {
+			if child == after {
+				at = i + 1
+				break
+			}
+		}
+		if at < 0 {
+			return nil, validationError("Block %s is not a child of %s.", req.After, id)
+		}
+	}
+
+	blocks := s.insertBlocks(id, req.Children, at)
+	return &listResponse{Object: notion.ObjectTypeList, Results: blocks, Type: "block"}, nil
+}
@@ -0,0 +1,400 @@
+package notiontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// AddDatabase creates a database directly, as if it had been created through
+// the API
+func (s *Server) AddDatabase(req *notion.CreateDatabaseRequest) (*notion.Database, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.insertDatabase(clone(*req))
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (s *Server) createDatabase(r *http.Request) (interface{}, *apiError) {
+	var req notion.CreateDatabaseRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	return s.insertDatabase(req)
+}
+
+// insertDatabase validates and stores a new database
+func (s *Server) insertDatabase(req notion.CreateDatabaseRequest) (*notion.Database, *apiError) {
+	if req.Parent == nil || req.Parent.PageID == "" {
+		return nil, validationError("body failed validation: body.parent.page_id should be defined, instead was `undefined`.")
+	}
+	parent, ok := s.pages[normalizeID(req.Parent.PageID)]
+	if !ok || parent.Archived {
+		return nil, notFound("page", req.Parent.PageID)
+	}
+
+	titles := 0
+	properties := map[string]notion.DatabaseProperty{}
+	for name, property := range req.Properties {
+		property, err := newSchemaProperty(name, property)
+		if err != nil {
+			return nil, err
+		}
+		if property.Type == notion.PropertyTypeTitle {
+			titles++
+			property.ID = "title"
+		}
+		properties[name] = property
+	}
+	if titles != 1 {
+		return nil, validationError("Databases must have exactly one title property, instead had %d.", titles)
+	}
+
+	id := newID()
+	now := s.timestamp()
+	db := &notion.Database{
+		Object:         notion.ObjectTypeDatabase,
+		ID:             id,
+		CreatedTime:    now,
+		CreatedBy:      s.botRef(),
+		LastEditedTime: now,
+		LastEditedBy:   s.botRef(),
+		Title:          req.Title,
+		Description:    req.Description,
+		Icon:           req.Icon,
+		Cover:          req.Cover,
+		Properties:     properties,
+		Parent:         &notion.Parent{Type: "page_id", PageID: parent.ID},
+		URL:            "https://www.notion.so/" + strings.ReplaceAll(id, "-", ""),
+		IsInline:       req.IsInline,
+	}
+	if db.Title == nil {
+		db.Title = []notion.RichText{}
+	}
+	s.databases[id] = db
+	s.addChildBlock(parent.ID, &notion.Block{
+		ID:            id,
+		Type:          notion.BlockTypeChildDatabase,
+		ChildDatabase: &notion.ChildDatabaseBlock{Title: notion.PlainText(db.Title)},
+	})
+
+	d := clone(*db)
+	return &d, nil
+}
+
+// newSchemaProperty assigns IDs to a new property and its options
+func newSchemaProperty(name string, property notion.DatabaseProperty) (notion.DatabaseProperty, *apiError) {
+	if property.Type == "" {
+		property.Type = schemaType(property)
+	}
+	if property.Type == "" {
+		return property, validationError("body failed validation: body.properties.%s should define a property type.", name)
+	}
+	if property.Name == "" {
+		property.Name = name
+	}
+	property.ID = shortID()
+
+	switch property.Type {
+	case notion.PropertyTypeSelect:
+		if property.Select == nil {
+			property.Select = &notion.SelectProperty{}
+		}
+		assignOptionIDs(property.Select.Options)
+	case notion.PropertyTypeMultiSelect:
+		if property.MultiSelect == nil {
+			property.MultiSelect = &notion.MultiSelectProperty{}
+		}
+		assignOptionIDs(property.MultiSelect.Options)
+	case notion.PropertyTypeStatus:
+		if property.Status == nil || len(property.Status.Options) == 0 {
+			property.Status = &notion.StatusProperty{Options: []notion.StatusOption{
+				{Name: "Not started", Color: "default"},
+				{Name: "In progress", Color: "blue"},
+				{Name: "Done", Color: "green"},
+			}}
+		}
+		for i := range property.Status.Options {
+			if property.Status.Options[i].ID == "" {
+				property.Status.Options[i].ID = shortID()
+			}
+		}
+	}
+	if property.Select != nil && property.Select.Options == nil {
+		property.Select.Options = []notion.SelectOption{}
+	}
+	if property.MultiSelect != nil && property.MultiSelect.Options == nil {
+		property.MultiSelect.Options = []notion.SelectOption{}
+	}
+	return property, nil
+}
+
+func assignOptionIDs(options []notion.SelectOption) {
+	for i := range options {
+		if options[i].ID == "" {
+			options[i].ID = shortID()
+		}
+		if options[i].Color == "" {
+			options[i].Color = "default"
+		}
+	}
+}
+
+// schemaType infers the type of a property from its configuration, since
+// requests may omit the type
+func schemaType(p notion.DatabaseProperty) string {
+	switch {
+	case p.Title != nil:
+		return notion.PropertyTypeTitle
+	case p.RichText != nil:
+		return notion.PropertyTypeRichText
+	case p.Number != nil:
+		return notion.PropertyTypeNumber
+	case p.Select != nil:
+		return notion.PropertyTypeSelect
+	case p.MultiSelect != nil:
+		return notion.PropertyTypeMultiSelect
+	case p.Status != nil:
+		return notion.PropertyTypeStatus
+	case p.Date != nil:
+		return notion.PropertyTypeDate
+	case p.People != nil:
+		return notion.PropertyTypePeople
+	case p.Files != nil:
+		return notion.PropertyTypeFiles
+	case p.Checkbox != nil:
+		return notion.PropertyTypeCheckbox
+	case p.URL != nil:
+		return notion.PropertyTypeURL
+	case p.Email != nil:
+		return notion.PropertyTypeEmail
+	case p.PhoneNumber != nil:
+		return notion.PropertyTypePhoneNumber
+	case p.Formula != nil:
+		return notion.PropertyTypeFormula
+	case p.Relation != nil:
+		return notion.PropertyTypeRelation
+	case p.Rollup != nil:
+		return notion.PropertyTypeRollup
+	case p.CreatedTime != nil:
+		return notion.PropertyTypeCreatedTime
+	case p.CreatedBy != nil:
+		return notion.PropertyTypeCreatedBy
+	case p.LastEditedTime != nil:
+		return notion.PropertyTypeLastEditedTime
+	case p.LastEditedBy != nil:
+		return notion.PropertyTypeLastEditedBy
+	}
+	return ""
+}
+
+func (s *Server) getDatabase(id string) (interface{}, *apiError) {
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, notFound("database", id)
+	}
+	return clone(*db), nil
+}
+
+func (s *Server) updateDatabase(r *http.Request, id string) (interface{}, *apiError) {
+	var req struct {
+		Title       []notion.RichText          `json:"title"`
+		Description []notion.RichText          `json:"description"`
+		Icon        *notion.Icon               `json:"icon"`
+		Cover       *notion.Cover              `json:"cover"`
+		Properties  map[string]json.RawMessage `json:"properties"`
+		Archived    *bool                      `json:"archived"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, notFound("database", id)
+	}
+
+	updated := clone(*db)
+	updated.LastEditedTime = s.timestamp()
+	updated.LastEditedBy = s.botRef()
+	if req.Title != nil {
+		updated.Title = req.Title
+	}
+	if req.Description != nil {
+		updated.Description = req.Description
+	}
+	if req.Icon != nil {
+		updated.Icon = req.Icon
+	}
+	if req.Cover != nil {
+		updated.Cover = req.Cover
+	}
+	if req.Archived != nil {
+		updated.Archived = *req.Archived
+	}
+
+	for key, raw := range req.Properties {
+		name, existing, exists := findProperty(&updated, key)
+		if string(raw) == "null" {
+			if !exists {
+				return nil, validationError("%s is not a property that exists.", key)
+			}
+			if existing.Type == notion.PropertyTypeTitle {
+				return nil, validationError("Cannot delete the title property.")
+			}
+			delete(updated.Properties, name)
+			continue
+		}
+
+		var change notion.DatabaseProperty
+		if err := json.Unmarshal(raw, &change); err != nil {
+			return nil, validationError("body failed validation: body.properties.%s is invalid.", key)
+		}
+		if !exists {
+			property, err := newSchemaProperty(key, change)
+			if err != nil {
+				return nil, err
+			}
+			if property.Type == notion.PropertyTypeTitle {
+				return nil, validationError("Databases can only have one title property.")
+			}
+			updated.Properties[property.Name] = property
+			continue
+		}
+
+		property := existing
+		changeType := change.Type
+		if changeType == "" {
+			changeType = schemaType(change)
+		}
+		if changeType != "" && changeType != existing.Type {
+			if existing.Type == notion.PropertyTypeTitle {
+				return nil, validationError("Cannot change the type of the title property.")
+			}
+			retyped, err := newSchemaProperty(name, change)
+			if err != nil {
+				return nil, err
+			}
+			retyped.ID = existing.ID
+			retyped.Name = existing.Name
+			property = retyped
+		} else {
+			mergeOptions(&property, change)
+		}
+		if change.Name != "" && change.Name != name {
+			if _, taken := updated.Properties[change.Name]; taken {
+				return nil, validationError("Property name %s already exists.", change.Name)
+			}
+			delete(updated.Properties, name)
+			name = change.Name
+		}
+		property.Name = name
+		updated.Properties[name] = property
+	}
+
+	*db = updated
+	s.reconcilePages(db)
+	if block, ok := s.blocks[id]; ok {
+		block.ChildDatabase.Title = notion.PlainText(db.Title)
+		block.Archived = db.Archived
+	}
+	return clone(updated), nil
+}
+
+// mergeOptions adds new select, multi-select and status options to a property
+func mergeOptions(property *notion.DatabaseProperty, change notion.DatabaseProperty) {
+	switch {
+	case change.Select != nil && property.Select != nil:
+		for _, option := range change.Select.Options {
+			selectOption(&property.Select.Options, option)
+		}
+	case change.MultiSelect != nil && property.MultiSelect != nil:
+		for _, option := range change.MultiSelect.Options {
+			selectOption(&property.MultiSelect.Options, option)
+		}
+	case change.Status != nil && property.Status != nil:
+		for _, option := range change.Status.Options {
+			found := false
+			for _, existing := range property.Status.Options {
+				if existing.Name == option.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				if option.ID == "" {
+					option.ID = shortID()
+				}
+				property.Status.Options = append(property.Status.Options, option)
+			}
+		}
+	case change.Number != nil && property.Number != nil:
+		property.Number = change.Number
+	}
+}
+
+// reconcilePages updates the properties of every page in the database after
+// a schema change, matching values by property ID
+func (s *Server) reconcilePages(db *notion.Database) {
+	for _, page := range s.pages {
+		if page.Parent.DatabaseID != db.ID {
+			continue
+		}
+		byID := map[string]notion.PageProperty{}
+		for _, value := range page.Properties {
+			byID[value.ID] = value
+		}
+		properties := map[string]notion.PageProperty{}
+		for name, schema := range db.Properties {
+			if value, ok := byID[schema.ID]; ok && value.Type == schema.Type {
+				properties[name] = value
+			} else {
+				properties[name] = emptyProperty(schema)
+			}
+		}
+		page.Properties = properties
+	}
+}
+
+func (s *Server) queryDatabase(r *http.Request, id string) (interface{}, *apiError) {
+	var req notion.QueryDatabaseRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	db, ok := s.databases[id]
+	if !ok {
+		return nil, notFound("database", id)
+	}
+
+	now := s.now().UTC()
+	evaluator := &notion.Evaluator{Now: func() time.Time { return now }}
+	pages := []notion.Page{}
+	for _, pageID := range s.pageOrder {
+		page := s.pages[pageID]
+		if page.Archived || page.Parent.DatabaseID != db.ID {
+			continue
+		}
+		match, err := evaluator.MatchFilter(page, req.Filter)
+		if err != nil {
+			return nil, validationError("%s", strings.TrimPrefix(err.Error(), "notion: "))
+		}
+		if match {
+			pages = append(pages, clone(*page))
+		}
+	}
+	if err := evaluator.SortPages(pages, req.Sorts); err != nil {
+		return nil, validationError("%s", strings.TrimPrefix(err.Error(), "notion: "))
+	}
+
+	list, err := paginate(pages, func(p notion.Page) string { return p.ID }, req.StartCursor, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	list.Type = "page_or_database"
+	return list, nil
+}
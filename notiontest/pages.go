@@ -0,0 +1,451 @@
+package notiontest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// readOnlyProperties are the property types computed by Notion
+var readOnlyProperties = map[string]bool{
+	notion.PropertyTypeFormula:        true,
+	notion.PropertyTypeRollup:         true,
+	notion.PropertyTypeCreatedTime:    true,
+	notion.PropertyTypeCreatedBy:      true,
+	notion.PropertyTypeLastEditedTime: true,
+	notion.PropertyTypeLastEditedBy:   true,
+	notion.PropertyTypeUniqueID:       true,
+}
+
+// AddPage creates a page directly, as if it had been created through the API
+func (s *Server) AddPage(req *notion.CreatePageRequest) (*notion.Page, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page, err := s.insertPage(clone(*req))
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// Page returns a copy of the stored page, or nil if it does not exist
+func (s *Server) Page(id string) *notion.Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page, ok := s.pages[normalizeID(id)]
+	if !ok {
+		return nil
+	}
+	p := clone(*page)
+	return &p
+}
+
+func (s *Server) createPage(r *http.Request) (interface{}, *apiError) {
+	var req notion.CreatePageRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	return s.insertPage(req)
+}
+
+// insertPage validates and stores a new page with its children
+func (s *Server) insertPage(req notion.CreatePageRequest) (*notion.Page, *apiError) {
+	if req.Parent == nil {
+		return nil, validationError("body failed validation: body.parent should be defined, instead was `undefined`.")
+	}
+
+	id := newID()
+	now := s.timestamp()
+	page := &notion.Page{
+		Object:         notion.ObjectTypePage,
+		ID:             id,
+		CreatedTime:    now,
+		CreatedBy:      s.botRef(),
+		LastEditedTime: now,
+		LastEditedBy:   s.botRef(),
+		Icon:           req.Icon,
+		Cover:          req.Cover,
+		URL:            "https://www.notion.so/" + strings.ReplaceAll(id, "-", ""),
+	}
+
+	switch {
+	case req.Parent.DatabaseID != "":
+		db, ok := s.databases[normalizeID(req.Parent.DatabaseID)]
+		if !ok || db.Archived {
+			return nil, notFound("database", req.Parent.DatabaseID)
+		}
+		page.Parent = &notion.Parent{Type: "database_id", DatabaseID: db.ID}
+		properties, err := s.setProperties(page, db, req.Properties)
+		if err != nil {
+			return nil, err
+		}
+		page.Properties = properties
+	case req.Parent.PageID != "":
+		parent, ok := s.pages[normalizeID(req.Parent.PageID)]
+		if !ok || parent.Archived {
+			return nil, notFound("page", req.Parent.PageID)
+		}
+		page.Parent = &notion.Parent{Type: "page_id", PageID: parent.ID}
+		title, err := titleProperty(req.Properties)
+		if err != nil {
+			return nil, err
+		}
+		page.Properties = map[string]notion.PageProperty{"title": title}
+	case req.Parent.Workspace:
+		page.Parent = &notion.Parent{Type: "workspace", Workspace: true}
+		title, err := titleProperty(req.Properties)
+		if err != nil {
+			return nil, err
+		}
+		page.Properties = map[string]notion.PageProperty{"title": title}
+	default:
+		return nil, validationError("body failed validation: body.parent should be a page_id, database_id or workspace parent.")
+	}
+
+	if err := validateBlocks("body.children", req.Children, 0); err != nil {
+		return nil, err
+	}
+
+	s.pages[id] = page
+	s.pageOrder = append(s.pageOrder, id)
+	if page.Parent.PageID != "" {
+		s.addChildBlock(page.Parent.PageID, &notion.Block{
+			ID:        id,
+			Type:      notion.BlockTypeChildPage,
+			ChildPage: &notion.ChildPageBlock{Title: notion.PlainText(page.Properties["title"].Title)},
+		})
+	}
+	s.insertBlocks(id, req.Children, -1)
+
+	p := clone(*page)
+	return &p, nil
+}
+
+// titleProperty reads the only property allowed on pages outside databases
+func titleProperty(properties map[string]notion.PageProperty) (notion.PageProperty, *apiError) {
+	title := notion.PageProperty{ID: "title", Type: notion.PropertyTypeTitle, Title: []notion.RichText{}}
+	for name, value := range properties {
+		if name != "title" || (value.Type != "" && value.Type != notion.PropertyTypeTitle) {
+			return title, validationError("Invalid property identifier: %s. Only \"title\" is allowed for pages outside databases.", name)
+		}
+		if value.Title != nil {
+			title.Title = value.Title
+		}
+	}
+	return title, nil
+}
+
+// setProperties applies property values to a database page, filling in every
+// property of the database schema
+func (s *Server) setProperties(page *notion.Page, db *notion.Database, values map[string]notion.PageProperty) (map[string]notion.PageProperty, *apiError) {
+	properties := map[string]notion.PageProperty{}
+	for name, schema := range db.Properties {
+		if value, ok := page.Properties[name]; ok {
+			properties[name] = value
+		} else {
+			properties[name] = emptyProperty(schema)
+		}
+	}
+
+	for key, value := range values {
+		name, schema, ok := findProperty(db, key)
+		if !ok {
+			return nil, validationError("%s is not a property that exists.", key)
+		}
+		if value.Type != "" && value.Type != schema.Type {
+			return nil, validationError("%s is expected to be %s.", name, schema.Type)
+		}
+		if readOnlyProperties[schema.Type] {
+			return nil, validationError("%s is a %s property and cannot be updated.", name, schema.Type)
+		}
+		value.ID = schema.ID
+		value.Type = schema.Type
+		value.HasMore = false
+		if err := s.resolveOptions(db, name, &value); err != nil {
+			return nil, err
+		}
+		properties[name] = value
+	}
+
+	// Computed properties reflect the page metadata
+	for name, value := range properties {
+		switch value.Type {
+		case notion.PropertyTypeCreatedTime:
+			value.CreatedTime = page.CreatedTime
+		case notion.PropertyTypeLastEditedTime:
+			value.LastEditedTime = page.LastEditedTime
+		case notion.PropertyTypeCreatedBy:
+			value.CreatedBy = page.CreatedBy
+		case notion.PropertyTypeLastEditedBy:
+			value.LastEditedBy = page.LastEditedBy
+		default:
+			continue
+		}
+		properties[name] = value
+	}
+	return properties, nil
+}
+
+// resolveOptions fills in option IDs and colors from the schema. Unknown
+// select and multi-select options are added to the schema, as Notion does;
+// unknown status options are rejected.
+func (s *Server) resolveOptions(db *notion.Database, name string, value *notion.PageProperty) *apiError {
+	schema := db.Properties[name]
+	switch schema.Type {
+	case notion.PropertyTypeSelect:
+		if value.Select != nil {
+			option := selectOption(&schema.Select.Options, *value.Select)
+			value.Select = &option
+		}
+	case notion.PropertyTypeMultiSelect:
+		for i, selected := range value.MultiSelect {
+			value.MultiSelect[i] = selectOption(&schema.MultiSelect.Options, selected)
+		}
+	case notion.PropertyTypeStatus:
+		if value.Status != nil {
+			found := false
+			for _, option := range schema.Status.Options {
+				if option.Name == value.Status.Name || (value.Status.ID != "" && option.ID == value.Status.ID) {
+					status := option
+					value.Status = &status
+					found = true
+					break
+				}
+			}
+			if !found {
+				return validationError("Invalid status option. Status option \"%s\" does not exist.", value.Status.Name)
+			}
+		}
+	case notion.PropertyTypeRelation:
+		for i, relation := range value.Relation {
+			value.Relation[i].ID = normalizeID(relation.ID)
+		}
+	case notion.PropertyTypePeople:
+		for i, user := range value.People {
+			value.People[i] = notion.User{Object: notion.ObjectTypeUser, ID: user.ID}
+		}
+	}
+	db.Properties[name] = schema
+	return nil
+}
+
+// selectOption finds an option by name or ID, adding it when missing
+func selectOption(options *[]notion.SelectOption, selected notion.SelectOption) notion.SelectOption {
+	for _, option := range *options {
+		if option.Name == selected.Name || (selected.ID != "" && option.ID == selected.ID) {
+			return option
+		}
+	}
+	option := notion.SelectOption{ID: shortID(), Name: selected.Name, Color: selected.Color}
+	if option.Color == "" {
+		option.Color = "default"
+	}
+	*options = append(*options, option)
+	return option
+}
+
+// findProperty looks up a database property by name or ID
+func findProperty(db *notion.Database, key string) (string, notion.DatabaseProperty, bool) {
+	if schema, ok := db.Properties[key]; ok {
+		return key, schema, true
+	}
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		key = unescaped
+	}
+	for name, schema := range db.Properties {
+		if schema.ID == key {
+			return name, schema, true
+		}
+	}
+	return "", notion.DatabaseProperty{}, false
+}
+
+// emptyProperty returns the value of a property that has not been set
+func emptyProperty(schema notion.DatabaseProperty) notion.PageProperty {
+	value := notion.PageProperty{ID: schema.ID, Type: schema.Type}
+	switch schema.Type {
+	case notion.PropertyTypeTitle:
+		value.Title = []notion.RichText{}
+	case notion.PropertyTypeRichText:
+		value.RichText = []notion.RichText{}
+	case notion.PropertyTypeFormula:
+		value.Formula = &notion.Formula{Type: "string"}
+	case notion.PropertyTypeRollup:
+		value.Rollup = &notion.Rollup{Type: "array"}
+	}
+	return value
+}
+
+func (s *Server) getPage(id string) (interface{}, *apiError) {
+	page, ok := s.pages[id]
+	if !ok {
+		return nil, notFound("page", id)
+	}
+	return clone(*page), nil
+}
+
+func (s *Server) updatePage(r *http.Request, id string) (interface{}, *apiError) {
+	var req struct {
+		Properties map[string]notion.PageProperty `json:"properties"`
+		Archived   *bool                          `json:"archived"`
+		InTrash    *bool                          `json:"in_trash"`
+		Icon       json.RawMessage                `json:"icon"`
+		Cover      json.RawMessage                `json:"cover"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	page, ok := s.pages[id]
+	if !ok {
+		return nil, notFound("page", id)
+	}
+	if req.Archived == nil {
+		req.Archived = req.InTrash
+	}
+	if page.Archived && (req.Archived == nil || *req.Archived) {
+		return nil, validationError("Can't edit block that is archived. You must unarchive the block before editing.")
+	}
+
+	updated := clone(*page)
+	updated.LastEditedTime = s.timestamp()
+	updated.LastEditedBy = s.botRef()
+	if req.Archived != nil {
+		updated.Archived = *req.Archived
+	}
+	if req.Icon != nil {
+		updated.Icon = nil
+		if string(req.Icon) != "null" {
+			if err := json.Unmarshal(req.Icon, &updated.Icon); err != nil {
+				return nil, validationError("body failed validation: body.icon is invalid.")
+			}
+		}
+	}
+	if req.Cover != nil {
+		updated.Cover = nil
+		if string(req.Cover) != "null" {
+			if err := json.Unmarshal(req.Cover, &updated.Cover); err != nil {
+				return nil, validationError("body failed validation: body.cover is invalid.")
+			}
+		}
+	}
+
+	if updated.Parent.DatabaseID != "" {
+		db := s.databases[updated.Parent.DatabaseID]
+		properties, err := s.setProperties(&updated, db, req.Properties)
+		if err != nil {
+			return nil, err
+		}
+		updated.Properties = properties
+	} else if len(req.Properties) > 0 {
+		title, err := titleProperty(req.Properties)
+		if err != nil {
+			return nil, err
+		}
+		updated.Properties["title"] = title
+		if block, ok := s.blocks[id]; ok {
+			block.ChildPage.Title = notion.PlainText(title.Title)
+		}
+	}
+
+	if block, ok := s.blocks[id]; ok {
+		block.Archived = updated.Archived
+	}
+	*page = updated
+	return clone(updated), nil
+}
+
+// propertyItemList is a paginated property item response
+type propertyItemList struct {
+	listResponse
+	PropertyItem notion.PropertyItemDetail `json:"property_item"`
+}
+
+func (s *Server) getPropertyItem(r *http.Request, pageID, propertyID string) (interface{}, *apiError) {
+	page, ok := s.pages[pageID]
+	if !ok {
+		return nil, notFound("page", pageID)
+	}
+	if unescaped, err := url.PathUnescape(propertyID); err == nil {
+		propertyID = unescaped
+	}
+	var value notion.PageProperty
+	found := false
+	for name, property := range page.Properties {
+		if property.ID == propertyID || name == propertyID {
+			value, found = property, true
+			break
+		}
+	}
+	if !found {
+		return nil, notFound("property", propertyID)
+	}
+
+	item := func() notion.PropertyItem {
+		return notion.PropertyItem{Object: "property_item", ID: value.ID, Type: value.Type}
+	}
+	var items []notion.PropertyItem
+	switch value.Type {
+	case notion.PropertyTypeTitle:
+		for i := range value.Title {
+			it := item()
+			it.Title = &value.Title[i]
+			items = append(items, it)
+		}
+	case notion.PropertyTypeRichText:
+		for i := range value.RichText {
+			it := item()
+			it.RichText = &value.RichText[i]
+			items = append(items, it)
+		}
+	case notion.PropertyTypeRelation:
+		for i := range value.Relation {
+			it := item()
+			it.Relation = &value.Relation[i]
+			items = append(items, it)
+		}
+	case notion.PropertyTypePeople:
+		for i := range value.People {
+			it := item()
+			it.People = &value.People[i]
+			items = append(items, it)
+		}
+	case notion.PropertyTypeRollup:
+		return &propertyItemList{
+			listResponse: listResponse{Object: notion.ObjectTypeList, Results: []notion.PropertyItem{}, Type: "property_item"},
+			PropertyItem: notion.PropertyItemDetail{ID: value.ID, Type: value.Type, Rollup: value.Rollup},
+		}, nil
+	default:
+		var it notion.PropertyItem
+		data, _ := json.Marshal(value)
+		json.Unmarshal(data, &it)
+		it.Object = "property_item"
+		return it, nil
+	}
+
+	cursor, pageSize, err := queryPagination(r)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make([]int, len(items))
+	for i := range indexed {
+		indexed[i] = i
+	}
+	list, err := paginate(indexed, strconv.Itoa, cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	results := []notion.PropertyItem{}
+	for _, i := range list.Results.([]int) {
+		results = append(results, items[i])
+	}
+	list.Results = results
+	list.Type = "property_item"
+	return &propertyItemList{
+		listResponse: *list,
+		PropertyItem: notion.PropertyItemDetail{ID: value.ID, Type: value.Type},
+	}, nil
+}
@@ -0,0 +1,190 @@
+package notiontest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// searchResult is a page or database returned by search
+type searchResult struct {
+	id             string
+	lastEditedTime string
+	value          interface{}
+}
+
+func (s *Server) search(r *http.Request) (interface{}, *apiError) {
+	var req notion.SearchRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	object := ""
+	if req.Filter != nil {
+		if req.Filter.Property != "object" || (req.Filter.Value != notion.ObjectTypePage && req.Filter.Value != notion.ObjectTypeDatabase) {
+			return nil, validationError("body failed validation: body.filter.value should be `\"page\"` or `\"database\"`, instead was `%q`.", req.Filter.Value)
+		}
+		object = req.Filter.Value
+	}
+	query := strings.ToLower(req.Query)
+
+	var results []searchResult
+	if object != notion.ObjectTypeDatabase {
+		for _, id := range s.pageOrder {
+			page := s.pages[id]
+			if page.Archived || !strings.Contains(strings.ToLower(pageTitle(page)), query) {
+				continue
+			}
+			results = append(results, searchResult{id, page.LastEditedTime, clone(*page)})
+		}
+	}
+	if object != notion.ObjectTypePage {
+		for _, db := range s.databases {
+			if db.Archived || !strings.Contains(strings.ToLower(notion.PlainText(db.Title)), query) {
+				continue
+			}
+			results = append(results, searchResult{db.ID, db.LastEditedTime, clone(*db)})
+		}
+	}
+
+	ascending := req.Sort != nil && req.Sort.Direction == notion.SortDirectionAscending
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.lastEditedTime == b.lastEditedTime {
+			return a.id < b.id
+		}
+		if ascending {
+			return a.lastEditedTime < b.lastEditedTime
+		}
+		return a.lastEditedTime > b.lastEditedTime
+	})
+
+	list, err := paginate(results, func(r searchResult) string { return r.id }, req.StartCursor, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	for _, result := range list.Results.([]searchResult) {
+		values = append(values, result.value)
+	}
+	list.Results = values
+	list.Type = "page_or_database"
+	return list, nil
+}
+
+// pageTitle returns the plain text of the title property of a page
+func pageTitle(page *notion.Page) string {
+	for _, property := range page.Properties {
+		if property.Type == notion.PropertyTypeTitle {
+			return notion.PlainText(property.Title)
+		}
+	}
+	return ""
+}
+
+// AddUser adds a workspace user returned by the users endpoints
+func (s *Server) AddUser(user notion.User) notion.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if user.ID == "" {
+		user.ID = newID()
+	}
+	if user.Type == "" {
+		user.Type = "person"
+	}
+	user.Object = notion.ObjectTypeUser
+	s.users = append(s.users, user)
+	return user
+}
+
+func (s *Server) listUsers(r *http.Request) (interface{}, *apiError) {
+	cursor, pageSize, err := queryPagination(r)
+	if err != nil {
+		return nil, err
+	}
+	list, err := paginate(s.users, func(u notion.User) string { return u.ID }, cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	list.Type = "user"
+	return list, nil
+}
+
+func (s *Server) getUser(id string) (interface{}, *apiError) {
+	if id == "me" {
+		return s.bot, nil
+	}
+	id = normalizeID(id)
+	for _, user := range s.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, notFound("user", id)
+}
+
+func (s *Server) createComment(r *http.Request) (interface{}, *apiError) {
+	var req notion.CreateCommentRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	comment := notion.Comment{
+		Object:    "comment",
+		ID:        newID(),
+		CreatedBy: s.botRef(),
+		RichText:  req.RichText,
+	}
+	comment.CreatedTime = s.timestamp()
+	comment.LastEditedTime = comment.CreatedTime
+	switch {
+	case req.DiscussionID != "":
+		for _, existing := range s.comments {
+			if existing.DiscussionID == req.DiscussionID {
+				comment.Parent = existing.Parent
+				comment.DiscussionID = req.DiscussionID
+				break
+			}
+		}
+		if comment.DiscussionID == "" {
+			return nil, notFound("discussion", req.DiscussionID)
+		}
+	case req.Parent != nil && req.Parent.PageID != "":
+		pageID := normalizeID(req.Parent.PageID)
+		if _, ok := s.pages[pageID]; !ok {
+			return nil, notFound("page", req.Parent.PageID)
+		}
+		comment.Parent = &notion.Parent{Type: "page_id", PageID: pageID}
+		comment.DiscussionID = newID()
+	default:
+		return nil, validationError("body failed validation: body.parent or body.discussion_id should be defined.")
+	}
+
+	s.comments = append(s.comments, comment)
+	return comment, nil
+}
+
+func (s *Server) listComments(r *http.Request) (interface{}, *apiError) {
+	blockID := normalizeID(r.URL.Query().Get("block_id"))
+	if !s.exists(blockID) {
+		return nil, notFound("block", blockID)
+	}
+	cursor, pageSize, err := queryPagination(r)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []notion.Comment{}
+	for _, comment := range s.comments {
+		if comment.Parent.PageID == blockID || comment.Parent.BlockID == blockID {
+			comments = append(comments, comment)
+		}
+	}
+	list, err := paginate(comments, func(c notion.Comment) string { return c.ID }, cursor, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	list.Type = "comment"
+	return list, nil
+}
@@ -0,0 +1,415 @@
+// Package notiontest provides an in-memory fake of the Notion API for tests.
+//
+// A Server keeps pages, databases, block trees, users and comments in memory
+// and serves the endpoints used by notion.Client, so tests can point a client
+// at it with notion.WithBaseURL:
+//
+//	server := notiontest.NewServer()
+//	defer server.Close()
+//	client := server.Client()
+package notiontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wujie1993/go-notion"
+)
+
+// timeFormat is the format of timestamps in Notion responses
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// maxPageSize is the largest page_size accepted by list endpoints
+const maxPageSize = 100
+
+// Server is an in-memory fake of the Notion API
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	now       func() time.Time
+	bot       notion.User
+	users     []notion.User
+	pages     map[string]*notion.Page
+	pageOrder []string
+	databases map[string]*notion.Database
+	blocks    map[string]*notion.Block
+	children  map[string][]string
+	comments  []notion.Comment
+	faults    []*Fault
+	requests  int
+}
+
+// NewServer starts a new fake Notion server. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		now:       time.Now,
+		pages:     map[string]*notion.Page{},
+		databases: map[string]*notion.Database{},
+		blocks:    map[string]*notion.Block{},
+		children:  map[string][]string{},
+	}
+	s.bot = notion.User{Object: notion.ObjectTypeUser, ID: newID(), Type: "bot", Name: "notiontest"}
+	s.users = []notion.User{s.bot}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a notion.Client that talks to the server
+func (s *Server) Client(options ...notion.ClientOption) *notion.Client {
+	options = append([]notion.ClientOption{notion.WithBaseURL(s.URL)}, options...)
+	return notion.NewClient("secret_notiontest", options...)
+}
+
+// SetClock sets the function used for created and last edited times
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Requests returns the number of requests the server has received
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Fault describes a failure injected into matching requests
+type Fault struct {
+	// Method and PathPrefix select the requests affected. Empty values match
+	// every request.
+	Method     string
+	PathPrefix string
+	// Latency delays the response
+	Latency time.Duration
+	// Status, if set, is returned instead of handling the request, with an
+	// error body carrying Code and Message. Code defaults to the code Notion
+	// uses for the status.
+	Status  int
+	Code    string
+	Message string
+	// RetryAfter sets the Retry-After header of the error response
+	RetryAfter time.Duration
+	// Times limits the number of requests affected. Zero means every request.
+	Times int
+
+	hits int
+}
+
+// InjectFault makes the server fail or delay matching requests
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// RateLimit makes the next n requests fail with 429 rate_limited
+func (s *Server) RateLimit(n int, retryAfter time.Duration) {
+	s.InjectFault(Fault{Status: http.StatusTooManyRequests, RetryAfter: retryAfter, Times: n})
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault returns the first fault matching the request, counting the hit
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for _, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+		if f.Times > 0 && f.hits >= f.Times {
+			continue
+		}
+		f.hits++
+		fault := *f
+		return &fault
+	}
+	return nil
+}
+
+// apiError is an error response in the Notion format
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func errorf(status int, code, format string, args ...interface{}) *apiError {
+	return &apiError{status: status, code: code, message: fmt.Sprintf(format, args...)}
+}
+
+func validationError(format string, args ...interface{}) *apiError {
+	return errorf(http.StatusBadRequest, notion.ErrorCodeValidation, format, args...)
+}
+
+func notFound(objectType, id string) *apiError {
+	return errorf(http.StatusNotFound, notion.ErrorCodeObjectNotFound,
+		"Could not find %s with ID: %s. Make sure the relevant pages and databases are shared with your integration.", objectType, id)
+}
+
+// defaultErrors holds the code and message Notion uses for each status
+var defaultErrors = map[int]apiError{
+	http.StatusBadRequest:          {code: notion.ErrorCodeValidation, message: "Request body failed validation."},
+	http.StatusUnauthorized:        {code: notion.ErrorCodeUnauthorized, message: "API token is invalid."},
+	http.StatusForbidden:           {code: notion.ErrorCodeRestrictedResource, message: "API token does not have access to this resource."},
+	http.StatusNotFound:            {code: notion.ErrorCodeObjectNotFound, message: "Could not find object."},
+	http.StatusConflict:            {code: notion.ErrorCodeConflict, message: "Conflict occurred while saving. Please try again."},
+	http.StatusTooManyRequests:     {code: notion.ErrorCodeRateLimited, message: "You have been rate limited. Please try again in a few minutes."},
+	http.StatusInternalServerError: {code: notion.ErrorCodeInternalServerError, message: "Unexpected error occurred."},
+	http.StatusBadGateway:          {code: notion.ErrorCodeBadGateway, message: "Notion encountered an issue while attempting to complete this request."},
+	http.StatusServiceUnavailable:  {code: notion.ErrorCodeServiceUnavailable, message: "Notion is unavailable, please try again later."},
+	http.StatusGatewayTimeout:      {code: notion.ErrorCodeGatewayTimeout, message: "Notion timed out while attempting to complete this request."},
+}
+
+func writeError(w http.ResponseWriter, e *apiError) {
+	requestID := newID()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-Id", requestID)
+	w.WriteHeader(e.status)
+	json.NewEncoder(w).Encode(notion.Error{
+		Object:    "error",
+		Status:    e.status,
+		Code:      e.code,
+		Message:   e.message,
+		RequestID: requestID,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// serveHTTP authenticates a request, applies faults and dispatches it
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	fault := s.takeFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			e := defaultErrors[fault.Status]
+			e.status = fault.Status
+			if fault.Code != "" {
+				e.code = fault.Code
+			}
+			if fault.Message != "" {
+				e.message = fault.Message
+			}
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, &e)
+			return
+		}
+	}
+
+	if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); token == "" || token == r.Header.Get("Authorization") {
+		writeError(w, errorf(http.StatusUnauthorized, notion.ErrorCodeUnauthorized, "API token is invalid."))
+		return
+	}
+	if r.Header.Get("Notion-Version") == "" {
+		writeError(w, errorf(http.StatusBadRequest, notion.ErrorCodeMissingVersion, "Notion-Version header failed validation: Notion-Version header should be defined."))
+		return
+	}
+
+	s.mu.Lock()
+	result, err := s.route(r)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, result)
+}
+
+// route dispatches a request to its handler
+func (s *Server) route(r *http.Request) (interface{}, *apiError) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := r.Method + " /" + parts[0]
+	if len(parts) > 1 {
+		route += "/{id}"
+	}
+	if len(parts) > 2 {
+		route += "/" + parts[2]
+	}
+	if len(parts) > 3 {
+		route += "/{id}"
+	}
+	id := func(i int) string {
+		return normalizeID(parts[i])
+	}
+
+	switch route {
+	case "POST /pages":
+		return s.createPage(r)
+	case "GET /pages/{id}":
+		return s.getPage(id(1))
+	case "PATCH /pages/{id}":
+		return s.updatePage(r, id(1))
+	case "GET /pages/{id}/properties/{id}":
+		return s.getPropertyItem(r, id(1), parts[3])
+	case "POST /databases":
+		return s.createDatabase(r)
+	case "GET /databases/{id}":
+		return s.getDatabase(id(1))
+	case "PATCH /databases/{id}":
+		return s.updateDatabase(r, id(1))
+	case "POST /databases/{id}/query":
+		return s.queryDatabase(r, id(1))
+	case "GET /blocks/{id}":
+		return s.getBlock(id(1))
+	case "PATCH /blocks/{id}":
+		return s.updateBlock(r, id(1))
+	case "DELETE /blocks/{id}":
+		return s.deleteBlock(id(1))
+	case "GET /blocks/{id}/children":
+		return s.listChildren(r, id(1))
+	case "PATCH /blocks/{id}/children":
+		return s.appendChildren(r, id(1))
+	case "POST /search":
+		return s.search(r)
+	case "GET /users":
+		return s.listUsers(r)
+	case "GET /users/{id}":
+		return s.getUser(parts[1])
+	case "POST /comments":
+		return s.createComment(r)
+	case "GET /comments":
+		return s.listComments(r)
+	}
+	return nil, errorf(http.StatusBadRequest, notion.ErrorCodeInvalidRequestURL, "Invalid request URL.")
+}
+
+// decode decodes a JSON request body
+func decode(r *http.Request, v interface{}) *apiError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, notion.ErrorCodeInvalidJSON, "Error parsing JSON body.")
+	}
+	return nil
+}
+
+// listResponse is a paginated list in the Notion format
+type listResponse struct {
+	Object     string      `json:"object"`
+	Results    interface{} `json:"results"`
+	NextCursor *string     `json:"next_cursor"`
+	HasMore    bool        `json:"has_more"`
+	Type       string      `json:"type,omitempty"`
+}
+
+// paginate returns one page of items. Cursors are the ID of the first item
+// of the next page, as in the real API.
+func paginate[T any](items []T, id func(T) string, cursor string, pageSize int) (*listResponse, *apiError) {
+	if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+	if pageSize < 0 || pageSize > maxPageSize {
+		return nil, validationError("body failed validation: body.page_size should be a number between 1 and %d, instead was `%d`.", maxPageSize, pageSize)
+	}
+
+	start := 0
+	if cursor != "" {
+		start = -1
+		for i, item := range items {
+			if id(item) == cursor {
+				start = i
+				break
+			}
+		}
+		if start < 0 {
+			return nil, validationError("start_cursor provided is invalid: %s", cursor)
+		}
+	}
+
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	resp := &listResponse{Object: "list", Results: items[start:end]}
+	if end < len(items) {
+		next := id(items[end])
+		resp.NextCursor = &next
+		resp.HasMore = true
+	}
+	return resp, nil
+}
+
+// queryPagination reads start_cursor and page_size from the query string
+func queryPagination(r *http.Request) (string, int, *apiError) {
+	query := r.URL.Query()
+	pageSize := 0
+	if value := query.Get("page_size"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", 0, validationError("page_size should be a number, instead was `%s`.", value)
+		}
+		pageSize = n
+	}
+	return query.Get("start_cursor"), pageSize, nil
+}
+
+// clone returns a deep copy of v, so that callers never share state with the server
+func clone[T any](v T) T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("notiontest: failed to copy %T: %v", v, err))
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("notiontest: failed to copy %T: %v", v, err))
+	}
+	return out
+}
+
+func newID() string {
+	return uuid.New().String()
+}
+
+// shortID returns an ID in the style of property and option IDs
+func shortID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")[:4]
+}
+
+// normalizeID accepts IDs with or without dashes, like the real API
+func normalizeID(id string) string {
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String()
+	}
+	return id
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(timeFormat)
+}
+
+func (s *Server) botRef() *notion.User {
+	return &notion.User{Object: notion.ObjectTypeUser, ID: s.bot.ID}
+}
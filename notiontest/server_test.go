@@ -0,0 +1,410 @@
+package notiontest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wujie1993/go-notion"
+)
+
+// newTaskDatabase creates a page holding a task database
+func newTaskDatabase(t *testing.T, server *Server) *notion.Database {
+	t.Helper()
+	root, err := server.AddPage(&notion.CreatePageRequest{
+		Parent:     &notion.Parent{Type: "workspace", Workspace: true},
+		Properties: map[string]notion.PageProperty{"title": notion.NewTitleProperty([]notion.RichText{notion.NewText("Root")})},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	db, err := server.AddDatabase(&notion.CreateDatabaseRequest{
+		Parent: notion.NewPageParent(root.ID),
+		Title:  []notion.RichText{notion.NewText("Tasks")},
+		Properties: map[string]notion.DatabaseProperty{
+			"Name":     {Type: notion.PropertyTypeTitle},
+			"Priority": {Type: notion.PropertyTypeNumber, Number: &notion.NumberProperty{Format: "number"}},
+			"Done":     {Type: notion.PropertyTypeCheckbox},
+			"Due":      {Type: notion.PropertyTypeDate},
+			"Tag":      {Type: notion.PropertyTypeSelect, Select: &notion.SelectProperty{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return db
+}
+
+func addTask(t *testing.T, client *notion.Client, databaseID, name string, priority float64, done bool, due string) *notion.Page {
+	t.Helper()
+	properties := map[string]notion.PageProperty{
+		"Name":     notion.NewTitleProperty([]notion.RichText{notion.NewText(name)}),
+		"Priority": notion.NewNumberProperty(priority),
+		"Done":     notion.NewCheckboxProperty(done),
+	}
+	if due != "" {
+		properties["Due"] = notion.NewDateProperty(notion.Date{Start: due})
+	}
+	page, err := client.CreatePage(context.Background(), &notion.CreatePageRequest{
+		Parent:     notion.NewDatabaseParent(databaseID),
+		Properties: properties,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return page
+}
+
+func TestPageCRUD(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	db := newTaskDatabase(t, server)
+
+	page := addTask(t, client, db.ID, "Write docs", 2, false, "")
+	if page.Properties["Tag"].Type != notion.PropertyTypeSelect {
+		t.Errorf("Expected every schema property to be present, got %+v", page.Properties)
+	}
+
+	updated, err := client.UpdatePage(ctx, page.ID, &notion.UpdatePageRequest{
+		Properties: map[string]notion.PageProperty{
+			"Done": notion.NewCheckboxProperty(true),
+			"Tag":  notion.NewSelectProperty(notion.SelectOption{Name: "docs"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !updated.Properties["Done"].Checkbox || updated.Properties["Tag"].Select.ID == "" {
+		t.Errorf("Expected the update to be applied, got %+v", updated.Properties)
+	}
+	if got := server.Page(page.ID).Properties["Priority"].Number; got == nil || *got != 2 {
+		t.Errorf("Expected untouched properties to be kept, got %v", got)
+	}
+	schema, _ := client.GetDatabase(ctx, db.ID)
+	if options := schema.Properties["Tag"].Select.Options; len(options) != 1 || options[0].Name != "docs" {
+		t.Errorf("Expected the new select option to be added to the schema, got %+v", options)
+	}
+
+	_, err = client.UpdatePage(ctx, page.ID, &notion.UpdatePageRequest{
+		Properties: map[string]notion.PageProperty{"Missing": notion.NewCheckboxProperty(true)},
+	})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Errorf("Expected a validation error for an unknown property, got %v", err)
+	}
+
+	_, err = client.GetPage(ctx, "00000000-0000-0000-0000-000000000000")
+	if !errors.Is(err, notion.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	var apiErr *notion.Error
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound || apiErr.RequestID == "" {
+		t.Errorf("Expected a Notion error body, got %+v", err)
+	}
+}
+
+func TestQueryDatabase(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetClock(func() time.Time { return time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC) })
+	client := server.Client()
+	ctx := context.Background()
+	db := newTaskDatabase(t, server)
+
+	addTask(t, client, db.ID, "Low", 1, false, "2024-05-10")
+	addTask(t, client, db.ID, "High", 5, false, "2024-05-16")
+	addTask(t, client, db.ID, "Done", 4, true, "")
+	addTask(t, client, db.ID, "Medium", 3, false, "2024-05-15T09:30:00.000Z")
+
+	filter, err := notion.Where("Done").Checkbox().IsFalse().
+		And(notion.Where("Priority").Number().GreaterThanOrEqualTo(2)).
+		Build()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	pages, err := client.QueryDatabaseAll(ctx, db.ID, &notion.QueryDatabaseRequest{
+		Filter: filter,
+		Sorts:  []notion.Sort{{Property: "Priority", Direction: notion.SortDirectionDescending}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := titles(pages); names != "High Medium" {
+		t.Errorf("Expected High Medium, got %s", names)
+	}
+
+	pages, err = client.QueryDatabaseAll(ctx, db.ID, &notion.QueryDatabaseRequest{
+		Filter: &notion.Filter{Property: "Due", Date: &notion.DateFilter{OnOrAfter: notion.DateToday}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := titles(pages); names != "High Medium" {
+		t.Errorf("Expected tasks due from today in creation order, got %s", names)
+	}
+
+	pages, err = client.QueryDatabaseAll(ctx, db.ID, &notion.QueryDatabaseRequest{
+		Sorts: []notion.Sort{{Property: "Due", Direction: notion.SortDirectionDescending}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if names := titles(pages); names != "High Medium Low Done" {
+		t.Errorf("Expected empty dates to sort last, got %s", names)
+	}
+
+	_, err = client.QueryDatabase(ctx, db.ID, &notion.QueryDatabaseRequest{
+		Filter: &notion.Filter{Property: "Priority", Checkbox: &notion.CheckboxFilter{}},
+	})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Errorf("Expected a validation error for a mismatched filter, got %v", err)
+	}
+}
+
+func titles(pages []notion.Page) string {
+	names := ""
+	for i, page := range pages {
+		if i > 0 {
+			names += " "
+		}
+		names += notion.PlainText(page.Properties["Name"].Title)
+	}
+	return names
+}
+
+func TestPagination(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	db := newTaskDatabase(t, server)
+	for i := 0; i < 5; i++ {
+		addTask(t, client, db.ID, "Task", float64(i), false, "")
+	}
+
+	first, err := client.QueryDatabase(ctx, db.ID, &notion.QueryDatabaseRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.Results) != 2 || !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("Expected a first page of 2 with a cursor, got %+v", first.ListResponse)
+	}
+	if first.NextCursor != server.pageOrder[3] {
+		t.Errorf("Expected the cursor to be the ID of the next page, got %s", first.NextCursor)
+	}
+
+	pages, err := client.QueryDatabasePaginator(db.ID, &notion.QueryDatabaseRequest{PageSize: 2}).All(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pages) != 5 {
+		t.Errorf("Expected 5 pages, got %d", len(pages))
+	}
+
+	_, err = client.QueryDatabase(ctx, db.ID, &notion.QueryDatabaseRequest{StartCursor: "bogus"})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Errorf("Expected a validation error for an invalid cursor, got %v", err)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	db := newTaskDatabase(t, server)
+	page := addTask(t, client, db.ID, "Notes", 1, false, "")
+
+	toggle := notion.NewToggleBlock([]notion.RichText{notion.NewText("More")}).
+		WithChildren(notion.NewParagraphBlock([]notion.RichText{notion.NewText("Hidden")}))
+	resp, err := client.AppendBlockChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{
+		Children: []notion.Block{*notion.NewHeading1Block([]notion.RichText{notion.NewText("Title")}), *toggle},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Results) != 2 || !resp.Results[1].HasChildren {
+		t.Fatalf("Expected 2 created blocks, got %+v", resp.Results)
+	}
+
+	_, err = client.AppendBlockChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{
+		Children: []notion.Block{*notion.NewDividerBlock()},
+		After:    resp.Results[0].ID,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tree, err := client.GetBlockTree(ctx, page.ID, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tree) != 3 || tree[1].Type != notion.BlockTypeDivider || len(tree[2].Children()) != 1 {
+		t.Fatalf("Expected heading, divider and toggle with a child, got %+v", tree)
+	}
+
+	if _, err := client.DeleteBlock(ctx, tree[1].ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if children := server.Children(page.ID); len(children) != 2 {
+		t.Errorf("Expected the deleted block to be hidden, got %d children", len(children))
+	}
+
+	deep := notion.NewBulletedListItemBlock([]notion.RichText{notion.NewText("1")}).WithChildren(
+		notion.NewBulletedListItemBlock([]notion.RichText{notion.NewText("2")}).WithChildren(
+			notion.NewBulletedListItemBlock([]notion.RichText{notion.NewText("3")}).WithChildren(
+				notion.NewBulletedListItemBlock([]notion.RichText{notion.NewText("4")}))))
+	_, err = client.AppendBlockChildren(ctx, page.ID, &notion.AppendBlockChildrenRequest{Children: []notion.Block{*deep}})
+	if !errors.Is(err, notion.ErrValidation) {
+		t.Errorf("Expected a validation error for nesting too deep, got %v", err)
+	}
+}
+
+func TestSearchUsersAndComments(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	db := newTaskDatabase(t, server)
+	page := addTask(t, client, db.ID, "Quarterly plan", 1, false, "")
+
+	results, err := client.SearchAll(ctx, &notion.SearchRequest{Query: "plan"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Page == nil || results[0].Page.ID != page.ID {
+		t.Errorf("Expected the matching page, got %+v", results)
+	}
+	databases, err := client.SearchDatabases(ctx, &notion.SearchRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(databases.Results) != 1 || databases.Results[0].ID != db.ID {
+		t.Errorf("Expected the database, got %+v", databases.Results)
+	}
+
+	server.AddUser(notion.User{Name: "Ada"})
+	users, err := client.ListUsers(ctx, "", 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(users.Results) != 2 {
+		t.Errorf("Expected the bot and a person, got %+v", users.Results)
+	}
+	me, err := client.GetMe(ctx)
+	if err != nil || me.Type != "bot" {
+		t.Errorf("Expected the bot user, got %+v, %v", me, err)
+	}
+
+	comment, err := client.CreateComment(ctx, &notion.CreateCommentRequest{
+		Parent:   notion.NewPageParent(page.ID),
+		RichText: []notion.RichText{notion.NewText("Looks good")},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	comments, err := client.ListAllComments(ctx, page.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(comments) != 1 || comments[0].DiscussionID != comment.DiscussionID {
+		t.Errorf("Expected the created comment, got %+v", comments)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	server.RateLimit(2, time.Second)
+	client := server.Client(notion.WithRetryPolicy(&notion.RetryPolicy{
+		MaxRetries: map[string]int{notion.ErrorCodeRateLimited: 3},
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   5 * time.Millisecond,
+	}))
+	if _, err := client.GetMe(ctx); err != nil {
+		t.Fatalf("Expected the retries to succeed, got %v", err)
+	}
+	if got := server.Requests(); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+
+	server.InjectFault(Fault{PathPrefix: "/users", Status: http.StatusServiceUnavailable, Times: 1})
+	_, err := server.Client().GetMe(ctx)
+	if !errors.Is(err, notion.ErrServiceUnavailable) {
+		t.Errorf("Expected ErrServiceUnavailable, got %v", err)
+	}
+
+	server.InjectFault(Fault{Latency: time.Second})
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := server.Client().GetMe(timeout); err == nil {
+		t.Error("Expected the injected latency to exceed the deadline")
+	}
+
+	server.ClearFaults()
+	if _, err := server.Client().GetMe(ctx); err != nil {
+		t.Errorf("Expected no error after clearing faults, got %v", err)
+	}
+}
+
+func TestMigrateDatabaseSchema(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	db := newTaskDatabase(t, server)
+
+	desired := map[string]notion.DatabaseProperty{
+		"Task":     {Type: notion.PropertyTypeTitle},
+		"Estimate": {ID: db.Properties["Priority"].ID, Type: notion.PropertyTypeNumber, Number: &notion.NumberProperty{Format: "number"}},
+		"Done":     {Type: notion.PropertyTypeCheckbox},
+		"Tag":      {Type: notion.PropertyTypeSelect, Select: &notion.SelectProperty{Options: []notion.SelectOption{{Name: "bug", Color: "red"}}}},
+		"Notes":    {Type: notion.PropertyTypeRichText},
+	}
+
+	var out strings.Builder
+	plan, err := client.MigrateDatabaseSchema(ctx, db.ID, desired, &notion.MigrateOptions{DryRun: true, Output: &out})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(plan.Changes) != 5 || !strings.Contains(out.String(), `- remove property "Due" (date)`) {
+		t.Errorf("Expected the dry run to print 5 changes, got:\n%s", out.String())
+	}
+	if got, _ := client.GetDatabase(ctx, db.ID); got.Properties["Due"].ID == "" {
+		t.Error("Expected the dry run to leave the database unchanged")
+	}
+
+	if _, err := client.MigrateDatabaseSchema(ctx, db.ID, desired, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got, err := client.GetDatabase(ctx, db.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := got.Properties["Due"]; ok {
+		t.Error("Expected Due to be removed")
+	}
+	if got.Properties["Task"].ID != "title" || got.Properties["Estimate"].ID != db.Properties["Priority"].ID {
+		t.Errorf("Expected Name and Priority to be renamed in place, got %v", got.Properties)
+	}
+	if got.Properties["Notes"].Type != notion.PropertyTypeRichText {
+		t.Errorf("Expected Notes to be added, got %v", got.Properties["Notes"])
+	}
+	if tag := got.Properties["Tag"].Select; tag == nil || len(tag.Options) != 1 || tag.Options[0].Name != "bug" {
+		t.Errorf("Expected the bug option to be added, got %v", got.Properties["Tag"])
+	}
+
+	plan, err = client.DiffDatabaseSchema(ctx, db.ID, desired)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Expected no changes after migrating, got:\n%s", plan)
+	}
+}
@@ -2,7 +2,10 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 // Page represents a Notion page
@@ -36,7 +39,7 @@ type PageProperty struct {
 	Relation       []Relation     `json:"relation,omitempty"`
 	Rollup         *Rollup        `json:"rollup,omitempty"`
 	People         []User         `json:"people,omitempty"`
-	Files          []File         `json:"files,omitempty"`
+	Files          []PropertyFile `json:"files,omitempty"`
 	Checkbox       bool           `json:"checkbox,omitempty"`
 	URL            string         `json:"url,omitempty"`
 	Email          string         `json:"email,omitempty"`
@@ -46,6 +49,98 @@ type PageProperty struct {
 	LastEditedTime string         `json:"last_edited_time,omitempty"`
 	LastEditedBy   *User          `json:"last_edited_by,omitempty"`
 	Status         *StatusOption  `json:"status,omitempty"`
+	UniqueID       *UniqueID      `json:"unique_id,omitempty"`
+	// HasMore is set on relation properties whose values were truncated
+	HasMore bool `json:"has_more,omitempty"`
+}
+
+// MarshalJSON encodes the property value matching Type, even when it is
+// false, zero or empty. Nil select, status, number and date values and empty
+// url, email and phone_number values are sent as null, which clears them.
+// Properties without a Type are encoded with every non-empty field.
+func (p PageProperty) MarshalJSON() ([]byte, error) {
+	type property PageProperty
+	value, ok := p.typedValue()
+	if !ok {
+		return json.Marshal(property(p))
+	}
+
+	fields := map[string]interface{}{
+		"type": p.Type,
+		p.Type: value,
+	}
+	if p.ID != "" {
+		fields["id"] = p.ID
+	}
+	if p.HasMore {
+		fields["has_more"] = true
+	}
+	return json.Marshal(fields)
+}
+
+// typedValue returns the value of the field matching Type, using empty
+// slices for nil lists and nil for empty nullable values
+func (p PageProperty) typedValue() (interface{}, bool) {
+	nullable := func(s string) interface{} {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+
+	switch p.Type {
+	case PropertyTypeTitle:
+		return nonNil(p.Title), true
+	case PropertyTypeRichText:
+		return nonNil(p.RichText), true
+	case PropertyTypeNumber:
+		return p.Number, true
+	case PropertyTypeSelect:
+		return p.Select, true
+	case PropertyTypeMultiSelect:
+		return nonNil(p.MultiSelect), true
+	case PropertyTypeStatus:
+		return p.Status, true
+	case PropertyTypeDate:
+		return p.Date, true
+	case PropertyTypePeople:
+		return nonNil(p.People), true
+	case PropertyTypeFiles:
+		return nonNil(p.Files), true
+	case PropertyTypeCheckbox:
+		return p.Checkbox, true
+	case PropertyTypeURL:
+		return nullable(p.URL), true
+	case PropertyTypeEmail:
+		return nullable(p.Email), true
+	case PropertyTypePhoneNumber:
+		return nullable(p.PhoneNumber), true
+	case PropertyTypeRelation:
+		return nonNil(p.Relation), true
+	case PropertyTypeFormula:
+		return p.Formula, true
+	case PropertyTypeRollup:
+		return p.Rollup, true
+	case PropertyTypeCreatedTime:
+		return p.CreatedTime, true
+	case PropertyTypeCreatedBy:
+		return p.CreatedBy, true
+	case PropertyTypeLastEditedTime:
+		return p.LastEditedTime, true
+	case PropertyTypeLastEditedBy:
+		return p.LastEditedBy, true
+	case PropertyTypeUniqueID:
+		return p.UniqueID, true
+	}
+	return nil, false
+}
+
+// nonNil returns an empty slice instead of nil so that it encodes as []
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
 }
 
 // Formula represents a formula result
@@ -57,6 +152,21 @@ type Formula struct {
 	Date    *Date    `json:"date,omitempty"`
 }
 
+// UniqueID represents the value of a unique ID property, such as TASK-12
+type UniqueID struct {
+	Prefix string `json:"prefix,omitempty"`
+	Number int    `json:"number"`
+}
+
+// String formats the ID as Notion shows it, such as TASK-12, or just the
+// number when there is no prefix
+func (id UniqueID) String() string {
+	if id.Prefix == "" {
+		return strconv.Itoa(id.Number)
+	}
+	return id.Prefix + "-" + strconv.Itoa(id.Number)
+}
+
 // Relation represents a relation to another page
 type Relation struct {
 	ID string `json:"id"`
@@ -84,7 +194,7 @@ type RollupValue struct {
 	Relation       []Relation     `json:"relation,omitempty"`
 	Rollup         *Rollup        `json:"rollup,omitempty"`
 	People         []User         `json:"people,omitempty"`
-	Files          []File         `json:"files,omitempty"`
+	Files          []PropertyFile `json:"files,omitempty"`
 	Checkbox       bool           `json:"checkbox,omitempty"`
 	URL            string         `json:"url,omitempty"`
 	Email          string         `json:"email,omitempty"`
@@ -95,6 +205,56 @@ type RollupValue struct {
 	LastEditedBy   *User          `json:"last_edited_by,omitempty"`
 }
 
+// PropertyItem represents a single value of a page property as returned by
+// the retrieve page property item endpoint. Paginated property types hold one
+// element per item: Title, RichText, Relation and People are single values.
+type PropertyItem struct {
+	Object         string         `json:"object"`
+	ID             string         `json:"id"`
+	Type           string         `json:"type"`
+	Title          *RichText      `json:"title,omitempty"`
+	RichText       *RichText      `json:"rich_text,omitempty"`
+	Relation       *Relation      `json:"relation,omitempty"`
+	People         *User          `json:"people,omitempty"`
+	Number         *float64       `json:"number,omitempty"`
+	Select         *SelectOption  `json:"select,omitempty"`
+	MultiSelect    []SelectOption `json:"multi_select,omitempty"`
+	Date           *Date          `json:"date,omitempty"`
+	Formula        *Formula       `json:"formula,omitempty"`
+	Rollup         *Rollup        `json:"rollup,omitempty"`
+	Files          []PropertyFile `json:"files,omitempty"`
+	Checkbox       bool           `json:"checkbox,omitempty"`
+	URL            string         `json:"url,omitempty"`
+	Email          string         `json:"email,omitempty"`
+	PhoneNumber    string         `json:"phone_number,omitempty"`
+	CreatedTime    string         `json:"created_time,omitempty"`
+	CreatedBy      *User          `json:"created_by,omitempty"`
+	LastEditedTime string         `json:"last_edited_time,omitempty"`
+	LastEditedBy   *User          `json:"last_edited_by,omitempty"`
+	Status         *StatusOption  `json:"status,omitempty"`
+}
+
+// PropertyItemResponse represents a response of the retrieve page property
+// item endpoint. For title, rich_text, relation, people and rollup properties
+// Object is "list", Results holds one page of items and Property
+// describes the property; for all other types the response is a single
+// property item.
+type PropertyItemResponse struct {
+	PropertyItem
+	NextCursor string              `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
+	Results    []PropertyItem      `json:"results,omitempty"`
+	Property   *PropertyItemDetail `json:"property_item,omitempty"`
+}
+
+// PropertyItemDetail describes the property of a paginated property item list
+type PropertyItemDetail struct {
+	ID      string  `json:"id"`
+	Type    string  `json:"type"`
+	NextURL string  `json:"next_url,omitempty"`
+	Rollup  *Rollup `json:"rollup,omitempty"`
+}
+
 // PagesListResponse represents a list of pages
 type PagesListResponse struct {
 	ListResponse
@@ -116,6 +276,31 @@ type UpdatePageRequest struct {
 	Archived   *bool                   `json:"archived,omitempty"`
 	Icon       *Icon                   `json:"icon,omitempty"`
 	Cover      *Cover                  `json:"cover,omitempty"`
+	// RemoveIcon removes the page icon by sending an explicit null
+	RemoveIcon bool `json:"-"`
+	// RemoveCover removes the page cover by sending an explicit null
+	RemoveCover bool `json:"-"`
+}
+
+// MarshalJSON encodes the request, sending null for a removed icon or cover
+func (r UpdatePageRequest) MarshalJSON() ([]byte, error) {
+	type request UpdatePageRequest
+	data, err := json.Marshal(request(r))
+	if err != nil || (!r.RemoveIcon && !r.RemoveCover) {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if r.RemoveIcon {
+		fields["icon"] = json.RawMessage("null")
+	}
+	if r.RemoveCover {
+		fields["cover"] = json.RawMessage("null")
+	}
+	return json.Marshal(fields)
 }
 
 // GetPage retrieves a page by ID
@@ -162,3 +347,215 @@ func (c *Client) UpdatePage(ctx context.Context, pageID string, req *UpdatePageR
 
 	return &page, nil
 }
+
+// GetPagePropertyItem retrieves a single page of a page property's items
+func (c *Client) GetPagePropertyItem(ctx context.Context, pageID, propertyID string, startCursor string, pageSize int) (*PropertyItemResponse, error) {
+	path := "/pages/" + pageID + "/properties/" + propertyID
+
+	query := url.Values{}
+	if startCursor != "" {
+		query.Set("start_cursor", startCursor)
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp, err := c.makeRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var item PropertyItemResponse
+	if err := parseResponse(resp, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse property item response: %w", err)
+	}
+
+	return &item, nil
+}
+
+// GetPageProperty retrieves the complete value of a page property, following
+// pagination for title, rich_text, relation, people and rollup properties
+// which GetPage truncates to 25 items
+func (c *Client) GetPageProperty(ctx context.Context, pageID, propertyID string) (*PageProperty, error) {
+	first, err := c.GetPagePropertyItem(ctx, pageID, propertyID, "", 100)
+	if err != nil {
+		return nil, err
+	}
+
+	if first.Object != ObjectTypeList {
+		return first.PropertyItem.pageProperty(), nil
+	}
+
+	items := first.Results
+	detail := first.Property
+	for resp := first; resp.HasMore && resp.NextCursor != ""; {
+		resp, err = c.GetPagePropertyItem(ctx, pageID, propertyID, resp.NextCursor, 100)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, resp.Results...)
+		if resp.Property != nil {
+			detail = resp.Property
+		}
+	}
+
+	return collectPropertyItems(propertyID, detail, items), nil
+}
+
+// GetPageWithFullProperties retrieves a page and fills in every property that
+// GetPage may have truncated
+func (c *Client) GetPageWithFullProperties(ctx context.Context, pageID string) (*Page, error) {
+	page, err := c.GetPage(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, prop := range page.Properties {
+		if !prop.mayBeTruncated() {
+			continue
+		}
+		full, err := c.GetPageProperty(ctx, page.ID, prop.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch property %q: %w", name, err)
+		}
+		page.Properties[name] = *full
+	}
+
+	return page, nil
+}
+
+// maxInlinePropertyItems is the number of items GetPage returns for a paginated property
+const maxInlinePropertyItems = 25
+
+// mayBeTruncated reports whether GetPage may have returned a partial value
+func (p PageProperty) mayBeTruncated() bool {
+	switch p.Type {
+	case PropertyTypeTitle:
+		return len(p.Title) >= maxInlinePropertyItems
+	case PropertyTypeRichText:
+		return len(p.RichText) >= maxInlinePropertyItems
+	case PropertyTypePeople:
+		return len(p.People) >= maxInlinePropertyItems
+	case PropertyTypeRelation:
+		return p.HasMore || len(p.Relation) >= maxInlinePropertyItems
+	case PropertyTypeRollup:
+		return true
+	}
+	return false
+}
+
+// pageProperty converts a single property item into a page property value
+func (item PropertyItem) pageProperty() *PageProperty {
+	return &PageProperty{
+		ID:             item.ID,
+		Type:           item.Type,
+		Number:         item.Number,
+		Select:         item.Select,
+		MultiSelect:    item.MultiSelect,
+		Date:           item.Date,
+		Formula:        item.Formula,
+		Rollup:         item.Rollup,
+		Files:          item.Files,
+		Checkbox:       item.Checkbox,
+		URL:            item.URL,
+		Email:          item.Email,
+		PhoneNumber:    item.PhoneNumber,
+		CreatedTime:    item.CreatedTime,
+		CreatedBy:      item.CreatedBy,
+		LastEditedTime: item.LastEditedTime,
+		LastEditedBy:   item.LastEditedBy,
+		Status:         item.Status,
+	}
+}
+
+// rollupValue converts a property item of a rollup list into a rollup array value
+func (item PropertyItem) rollupValue() RollupValue {
+	value := RollupValue{
+		Type:           item.Type,
+		Number:         item.Number,
+		Select:         item.Select,
+		MultiSelect:    item.MultiSelect,
+		Date:           item.Date,
+		Formula:        item.Formula,
+		Rollup:         item.Rollup,
+		Files:          item.Files,
+		Checkbox:       item.Checkbox,
+		URL:            item.URL,
+		Email:          item.Email,
+		PhoneNumber:    item.PhoneNumber,
+		CreatedTime:    item.CreatedTime,
+		CreatedBy:      item.CreatedBy,
+		LastEditedTime: item.LastEditedTime,
+		LastEditedBy:   item.LastEditedBy,
+	}
+	if item.Title != nil {
+		value.Title = []RichText{*item.Title}
+	}
+	if item.RichText != nil {
+		value.RichText = []RichText{*item.RichText}
+	}
+	if item.Relation != nil {
+		value.Relation = []Relation{*item.Relation}
+	}
+	if item.People != nil {
+		value.People = []User{*item.People}
+	}
+	return value
+}
+
+// collectPropertyItems assembles the items of a paginated property into a page property value
+func collectPropertyItems(propertyID string, detail *PropertyItemDetail, items []PropertyItem) *PageProperty {
+	prop := &PageProperty{ID: propertyID}
+	if detail != nil {
+		prop.ID = detail.ID
+		prop.Type = detail.Type
+	}
+
+	switch prop.Type {
+	case PropertyTypeTitle:
+		prop.Title = []RichText{}
+		for _, item := range items {
+			if item.Title != nil {
+				prop.Title = append(prop.Title, *item.Title)
+			}
+		}
+	case PropertyTypeRichText:
+		prop.RichText = []RichText{}
+		for _, item := range items {
+			if item.RichText != nil {
+				prop.RichText = append(prop.RichText, *item.RichText)
+			}
+		}
+	case PropertyTypeRelation:
+		prop.Relation = []Relation{}
+		for _, item := range items {
+			if item.Relation != nil {
+				prop.Relation = append(prop.Relation, *item.Relation)
+			}
+		}
+	case PropertyTypePeople:
+		prop.People = []User{}
+		for _, item := range items {
+			if item.People != nil {
+				prop.People = append(prop.People, *item.People)
+			}
+		}
+	case PropertyTypeRollup:
+		prop.Rollup = &Rollup{}
+		if detail != nil && detail.Rollup != nil {
+			*prop.Rollup = *detail.Rollup
+		}
+		if prop.Rollup.Type == "array" || prop.Rollup.Type == "" {
+			prop.Rollup.Type = "array"
+			prop.Rollup.Array = make([]RollupValue, 0, len(items))
+			for _, item := range items {
+				prop.Rollup.Array = append(prop.Rollup.Array, item.rollupValue())
+			}
+		}
+	}
+
+	return prop
+}
@@ -0,0 +1,152 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func relationItems(from, to int) string {
+	var items []string
+	for i := from; i < to; i++ {
+		items = append(items, fmt.Sprintf(`{"object":"property_item","id":"rel","type":"relation","relation":{"id":"page-%d"}}`, i))
+	}
+	return strings.Join(items, ",")
+}
+
+func relations(from, to int) string {
+	var items []string
+	for i := from; i < to; i++ {
+		items = append(items, fmt.Sprintf(`{"id":"page-%d"}`, i))
+	}
+	return strings.Join(items, ",")
+}
+
+func newPropertyServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/pages/page-id":
+			fmt.Fprintf(w, `{"object":"page","id":"page-id","properties":{
+				"Related":{"id":"rel","type":"relation","relation":[%s],"has_more":true},
+				"Score":{"id":"num","type":"number","number":3}
+			}}`, relations(0, 25))
+		case r.URL.Path == "/pages/page-id/properties/rel" && r.URL.Query().Get("start_cursor") == "":
+			fmt.Fprintf(w, `{"object":"list","results":[%s],"has_more":true,"next_cursor":"c1+a/b=&c","type":"property_item","property_item":{"id":"rel","type":"relation","relation":{}}}`, relationItems(0, 100))
+		case r.URL.Path == "/pages/page-id/properties/rel" && r.URL.Query().Get("start_cursor") == "c1+a/b=&c":
+			fmt.Fprintf(w, `{"object":"list","results":[%s],"has_more":false,"type":"property_item","property_item":{"id":"rel","type":"relation","relation":{}}}`, relationItems(100, 130))
+		case r.URL.Path == "/pages/page-id/properties/num":
+			w.Write([]byte(`{"object":"property_item","id":"num","type":"number","number":3}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetPageProperty(t *testing.T) {
+	server := newPropertyServer(t)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+
+	relation, err := client.GetPageProperty(context.Background(), "page-id", "rel")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if relation.Type != PropertyTypeRelation {
+		t.Errorf("Expected type 'relation', got '%s'", relation.Type)
+	}
+	if len(relation.Relation) != 130 || relation.Relation[129].ID != "page-129" {
+		t.Errorf("Expected 130 relations ending with page-129, got %d", len(relation.Relation))
+	}
+
+	number, err := client.GetPageProperty(context.Background(), "page-id", "num")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if number.Type != PropertyTypeNumber || number.Number == nil || *number.Number != 3 {
+		t.Errorf("Expected number 3, got %+v", number)
+	}
+}
+
+func TestGetPageWithFullProperties(t *testing.T) {
+	server := newPropertyServer(t)
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	page, err := client.GetPageWithFullProperties(context.Background(), "page-id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n := len(page.Properties["Related"].Relation); n != 130 {
+		t.Errorf("Expected 130 relations, got %d", n)
+	}
+	if score := page.Properties["Score"].Number; score == nil || *score != 3 {
+		t.Errorf("Expected score to be kept, got %v", score)
+	}
+}
+
+func TestPagePropertyMarshalJSON(t *testing.T) {
+	zero := 0.0
+	tests := []struct {
+		name     string
+		prop     PageProperty
+		expected string
+	}{
+		{"title", NewTitleProperty([]RichText{NewText("a")}), `{"title":[{"type":"text","text":{"content":"a"},"plain_text":"a"}],"type":"title"}`},
+		{"empty title", NewEmptyProperty(PropertyTypeTitle), `{"title":[],"type":"title"}`},
+		{"empty rich text", NewEmptyProperty(PropertyTypeRichText), `{"rich_text":[],"type":"rich_text"}`},
+		{"zero number", NewNumberProperty(0), `{"number":0,"type":"number"}`},
+		{"cleared number", NewEmptyProperty(PropertyTypeNumber), `{"number":null,"type":"number"}`},
+		{"cleared select", NewEmptyProperty(PropertyTypeSelect), `{"select":null,"type":"select"}`},
+		{"cleared status", NewEmptyProperty(PropertyTypeStatus), `{"status":null,"type":"status"}`},
+		{"empty multi select", NewMultiSelectProperty(nil), `{"multi_select":[],"type":"multi_select"}`},
+		{"cleared date", NewEmptyProperty(PropertyTypeDate), `{"date":null,"type":"date"}`},
+		{"empty people", NewEmptyProperty(PropertyTypePeople), `{"people":[],"type":"people"}`},
+		{"empty files", NewEmptyProperty(PropertyTypeFiles), `{"files":[],"type":"files"}`},
+		{"false checkbox", NewCheckboxProperty(false), `{"checkbox":false,"type":"checkbox"}`},
+		{"true checkbox", NewCheckboxProperty(true), `{"checkbox":true,"type":"checkbox"}`},
+		{"cleared url", NewURLProperty(""), `{"type":"url","url":null}`},
+		{"cleared email", NewEmailProperty(""), `{"email":null,"type":"email"}`},
+		{"cleared phone", NewPhoneNumberProperty(""), `{"phone_number":null,"type":"phone_number"}`},
+		{"empty relation", NewRelationProperty(nil), `{"relation":[],"type":"relation"}`},
+		{"with id", PageProperty{ID: "abc", Type: PropertyTypeNumber, Number: &zero}, `{"id":"abc","number":0,"type":"number"}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.prop)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.expected, data)
+		}
+
+		var decoded PageProperty
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("%s: expected no error, got %v", tt.name, err)
+		}
+		again, _ := json.Marshal(decoded)
+		if string(again) != tt.expected {
+			t.Errorf("%s: expected round trip to give %s, got %s", tt.name, tt.expected, again)
+		}
+	}
+}
+
+func TestUpdatePageRequestMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&UpdatePageRequest{
+		Properties: map[string]PageProperty{"Done": NewCheckboxProperty(false)},
+		RemoveIcon: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"icon":null,"properties":{"Done":{"checkbox":false,"type":"checkbox"}}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
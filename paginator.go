@@ -0,0 +1,101 @@
+package notion
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoMoreItems is returned by Paginator.Next when all items have been read
+var ErrNoMoreItems = errors.New("notion: no more items")
+
+// PageFetcher fetches a single page of a paginated list starting at cursor.
+// An empty cursor requests the first page.
+type PageFetcher[T any] func(ctx context.Context, cursor string) ([]T, ListResponse, error)
+
+// Paginator streams the items of a paginated list endpoint, fetching one page
+// at a time as items are consumed. A Paginator is not safe for concurrent use.
+type Paginator[T any] struct {
+	fetch    PageFetcher[T]
+	cursor   string
+	buffer   []T
+	hasMore  bool
+	maxItems int
+	returned int
+	err      error
+}
+
+// NewPaginator creates a paginator over the pages returned by fetch
+func NewPaginator[T any](fetch PageFetcher[T]) *Paginator[T] {
+	return &Paginator[T]{
+		fetch:   fetch,
+		hasMore: true,
+	}
+}
+
+// WithMaxItems caps the number of items the paginator returns. Zero means no cap.
+func (p *Paginator[T]) WithMaxItems(maxItems int) *Paginator[T] {
+	p.maxItems = maxItems
+	return p
+}
+
+// Next returns the next item, fetching the next page when needed. It returns
+// ErrNoMoreItems once the list or the max items cap is exhausted.
+func (p *Paginator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	if p.err != nil {
+		return zero, p.err
+	}
+	if p.maxItems > 0 && p.returned >= p.maxItems {
+		return zero, ErrNoMoreItems
+	}
+
+	for len(p.buffer) == 0 {
+		if !p.hasMore {
+			return zero, ErrNoMoreItems
+		}
+		items, list, err := p.fetch(ctx, p.cursor)
+		if err != nil {
+			p.err = err
+			return zero, err
+		}
+		p.buffer = items
+		p.cursor = list.NextCursor
+		p.hasMore = list.HasMore && list.NextCursor != ""
+	}
+
+	item := p.buffer[0]
+	p.buffer[0] = zero
+	p.buffer = p.buffer[1:]
+	p.returned++
+	return item, nil
+}
+
+// ForEach calls fn for every remaining item. It stops at the first error
+// returned by fn or by the API.
+func (p *Paginator[T]) ForEach(ctx context.Context, fn func(item T) error) error {
+	for {
+		item, err := p.Next(ctx)
+		if errors.Is(err, ErrNoMoreItems) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// All reads every remaining item into a slice
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var items []T
+	err := p.ForEach(ctx, func(item T) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,114 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPaginatorStreamsPages(t *testing.T) {
+	fetches := 0
+	paginator := NewPaginator(func(ctx context.Context, cursor string) ([]int, ListResponse, error) {
+		fetches++
+		switch cursor {
+		case "":
+			return []int{1, 2}, ListResponse{HasMore: true, NextCursor: "a"}, nil
+		case "a":
+			return []int{}, ListResponse{HasMore: true, NextCursor: "b"}, nil
+		case "b":
+			return []int{3}, ListResponse{HasMore: false}, nil
+		}
+		return nil, ListResponse{}, fmt.Errorf("unexpected cursor %q", cursor)
+	})
+
+	ctx := context.Background()
+	first, err := paginator.Next(ctx)
+	if err != nil || first != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", first, err)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected a single fetch before the first page is consumed, got %d", fetches)
+	}
+
+	rest, err := paginator.All(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if fmt.Sprint(rest) != "[2 3]" {
+		t.Errorf("Expected [2 3], got %v", rest)
+	}
+	if _, err := paginator.Next(ctx); !errors.Is(err, ErrNoMoreItems) {
+		t.Errorf("Expected ErrNoMoreItems, got %v", err)
+	}
+}
+
+func TestPaginatorMaxItems(t *testing.T) {
+	fetches := 0
+	paginator := NewPaginator(func(ctx context.Context, cursor string) ([]int, ListResponse, error) {
+		fetches++
+		return []int{1, 2, 3}, ListResponse{HasMore: true, NextCursor: "next"}, nil
+	}).WithMaxItems(4)
+
+	items, err := paginator.All(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(items) != 4 {
+		t.Errorf("Expected 4 items, got %d", len(items))
+	}
+	if fetches != 2 {
+		t.Errorf("Expected 2 fetches, got %d", fetches)
+	}
+}
+
+func TestPaginatorForEachStopsOnError(t *testing.T) {
+	paginator := NewPaginator(func(ctx context.Context, cursor string) ([]int, ListResponse, error) {
+		return []int{1, 2, 3}, ListResponse{}, nil
+	})
+
+	stop := errors.New("stop")
+	seen := 0
+	err := paginator.ForEach(context.Background(), func(item int) error {
+		seen++
+		if item == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected stop error, got %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("Expected 2 items to be seen, got %d", seen)
+	}
+}
+
+func TestQueryDatabaseAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req QueryDatabaseRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.StartCursor == "" {
+			w.Write([]byte(`{"object":"list","results":[{"object":"page","id":"1"}],"has_more":true,"next_cursor":"c1"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"list","results":[{"object":"page","id":"2"}],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	req := &QueryDatabaseRequest{PageSize: 1}
+	pages, err := client.QueryDatabaseAll(context.Background(), "db-id", req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(pages) != 2 || pages[0].ID != "1" || pages[1].ID != "2" {
+		t.Errorf("Expected pages 1 and 2, got %+v", pages)
+	}
+	if req.StartCursor != "" {
+		t.Error("Expected the caller's request not to be modified")
+	}
+}
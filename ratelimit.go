@@ -0,0 +1,99 @@
+package notion
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerSecond is the average request rate Notion allows per integration
+const DefaultRequestsPerSecond = 3
+
+// RateLimiter is a token bucket rate limiter that is safe for concurrent use.
+// A single RateLimiter can be shared by several clients that use the same
+// integration token.
+type RateLimiter struct {
+	mu sync.Mutex
+	// interval is the time it takes to earn a token; zero means no limit
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter creates a rate limiter that allows requestsPerSecond requests
+// on average and bursts of up to burst requests. A rate of zero or less means
+// no limit.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if requestsPerSecond <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if err := sleepContext(ctx, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// RateLimitMiddleware makes every request wait on limiter before it is sent
+func RateLimitMiddleware(limiter *RateLimiter) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller has
+// to wait before that token becomes available
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.interval == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// cancel returns a token that was reserved but never used
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.interval == 0 {
+		return
+	}
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
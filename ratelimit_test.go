@@ -0,0 +1,97 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := NewRateLimiter(10, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Expected burst to pass without waiting, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected request after burst to wait ~100ms, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterNoLimit(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewRateLimiter(rate, 1)
+		start := time.Now()
+		for i := 0; i < 100; i++ {
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+			t.Errorf("Expected a rate of %v not to limit requests, took %v", rate, elapsed)
+		}
+	}
+}
+
+func TestRateLimiterContextCancellation(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline error, got %v", err)
+	}
+}
+
+func TestRateLimiterSharedAcrossClients(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"object":"user","id":"me"}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(20, 2)
+	clients := []*Client{
+		NewClient("key", WithBaseURL(server.URL), WithRateLimiter(limiter)),
+		NewClient("key", WithBaseURL(server.URL), WithRateLimiter(limiter)),
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			if _, err := c.GetMe(context.Background()); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}(clients[i%2])
+	}
+	wg.Wait()
+
+	// 2 requests pass immediately, the remaining 4 are spaced 50ms apart
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("Expected shared limiter to throttle both clients, took %v", elapsed)
+	}
+	if calls != 6 {
+		t.Errorf("Expected 6 calls, got %d", calls)
+	}
+}
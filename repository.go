@@ -0,0 +1,152 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Repository provides typed access to the pages of a database. T must be a
+// struct tagged as described for MarshalProperties.
+type Repository[T any] struct {
+	client     *Client
+	databaseID string
+	database   *Database
+}
+
+// NewRepository creates a repository over a database, checking once that
+// every tagged field of T matches a database property of the same type
+func NewRepository[T any](ctx context.Context, client *Client, databaseID string) (*Repository[T], error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("notion: repository type %T must be a struct", zero)
+	}
+
+	fields, err := propertyFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := client.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fields {
+		if f.propType == propertyTypeID {
+			continue
+		}
+		prop, ok := database.Properties[f.name]
+		if !ok {
+			return nil, f.errorf("property not found in database %s", databaseID)
+		}
+		if prop.Type != f.propType {
+			return nil, f.errorf("database property has type %s", prop.Type)
+		}
+	}
+
+	return &Repository[T]{
+		client:     client,
+		databaseID: databaseID,
+		database:   database,
+	}, nil
+}
+
+// Database returns the database schema the repository was validated against
+func (r *Repository[T]) Database() *Database {
+	return r.database
+}
+
+// Get retrieves a page by ID
+func (r *Repository[T]) Get(ctx context.Context, pageID string) (T, error) {
+	page, err := r.client.GetPage(ctx, pageID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return r.decode(page)
+}
+
+// Create creates a new page in the database from v
+func (r *Repository[T]) Create(ctx context.Context, v T) (T, error) {
+	var zero T
+	properties, err := MarshalProperties(v)
+	if err != nil {
+		return zero, err
+	}
+
+	page, err := r.client.CreatePage(ctx, &CreatePageRequest{
+		Parent:     NewDatabaseParent(r.databaseID),
+		Properties: properties,
+	})
+	if err != nil {
+		return zero, err
+	}
+	return r.decode(page)
+}
+
+// Update replaces the properties of a page with the values of v
+func (r *Repository[T]) Update(ctx context.Context, pageID string, v T) (T, error) {
+	var zero T
+	properties, err := MarshalProperties(v)
+	if err != nil {
+		return zero, err
+	}
+
+	page, err := r.client.UpdatePage(ctx, pageID, &UpdatePageRequest{
+		Properties: properties,
+	})
+	if err != nil {
+		return zero, err
+	}
+	return r.decode(page)
+}
+
+// Archive archives a page
+func (r *Repository[T]) Archive(ctx context.Context, pageID string) error {
+	archived := true
+	_, err := r.client.UpdatePage(ctx, pageID, &UpdatePageRequest{
+		Archived: &archived,
+	})
+	return err
+}
+
+// Query returns every page matching filter, ordered by sorts
+func (r *Repository[T]) Query(ctx context.Context, filter *Filter, sorts []Sort) ([]T, error) {
+	return r.Iterator(filter, sorts).All(ctx)
+}
+
+// Iterator returns a paginator over the pages matching filter, ordered by sorts
+func (r *Repository[T]) Iterator(filter *Filter, sorts []Sort) *Paginator[T] {
+	query := QueryDatabaseRequest{
+		Filter: filter,
+		Sorts:  sorts,
+	}
+	return NewPaginator(func(ctx context.Context, cursor string) ([]T, ListResponse, error) {
+		query.StartCursor = cursor
+		resp, err := r.client.QueryDatabase(ctx, r.databaseID, &query)
+		if err != nil {
+			return nil, ListResponse{}, err
+		}
+
+		items := make([]T, 0, len(resp.Results))
+		for i := range resp.Results {
+			item, err := r.decode(&resp.Results[i])
+			if err != nil {
+				return nil, ListResponse{}, err
+			}
+			items = append(items, item)
+		}
+		return items, resp.ListResponse, nil
+	})
+}
+
+// decode converts a page into a T
+func (r *Repository[T]) decode(page *Page) (T, error) {
+	var v T
+	if err := UnmarshalProperties(page, &v); err != nil {
+		return v, err
+	}
+	return v, nil
+}
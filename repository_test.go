@@ -0,0 +1,95 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testContact struct {
+	ID    string `notion:",id"`
+	Name  string `notion:"Name,title"`
+	Email string `notion:"Email,email"`
+}
+
+const contactsDatabaseJSON = `{"object":"database","id":"db-id","properties":{
+	"Name":{"id":"title","name":"Name","type":"title","title":{}},
+	"Email":{"id":"em","name":"Email","type":"email","email":{}}
+}}`
+
+func contactPageJSON(id, name, email string) string {
+	return `{"object":"page","id":"` + id + `","properties":{
+		"Name":{"id":"title","type":"title","title":[{"type":"text","plain_text":"` + name + `"}]},
+		"Email":{"id":"em","type":"email","email":"` + email + `"}
+	}}`
+}
+
+func TestRepository(t *testing.T) {
+	var created map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /databases/db-id":
+			w.Write([]byte(contactsDatabaseJSON))
+		case "POST /pages":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(contactPageJSON("page-1", "Ada", "ada@example.com")))
+		case "POST /databases/db-id/query":
+			var req QueryDatabaseRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.StartCursor == "" {
+				w.Write([]byte(`{"object":"list","results":[` + contactPageJSON("page-1", "Ada", "ada@example.com") + `],"has_more":true,"next_cursor":"c1"}`))
+				return
+			}
+			w.Write([]byte(`{"object":"list","results":[` + contactPageJSON("page-2", "Grace", "grace@example.com") + `],"has_more":false}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("key", WithBaseURL(server.URL))
+	repo, err := NewRepository[testContact](ctx, client, "db-id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	contact, err := repo.Create(ctx, testContact{Name: "Ada", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if contact.ID != "page-1" || contact.Name != "Ada" {
+		t.Errorf("Expected created contact page-1 named Ada, got %+v", contact)
+	}
+	if parent, _ := created["parent"].(map[string]interface{}); parent["database_id"] != "db-id" {
+		t.Errorf("Expected page to be created in db-id, got %v", created["parent"])
+	}
+
+	contacts, err := repo.Query(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(contacts) != 2 || contacts[1].Email != "grace@example.com" {
+		t.Errorf("Expected 2 contacts, got %+v", contacts)
+	}
+}
+
+func TestNewRepositorySchemaMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(contactsDatabaseJSON))
+	}))
+	defer server.Close()
+
+	type wrong struct {
+		Email string `notion:"Email,url"`
+	}
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	_, err := NewRepository[wrong](context.Background(), client, "db-id")
+	if err == nil || !strings.Contains(err.Error(), "database property has type email") {
+		t.Errorf("Expected schema mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package notion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests
+type RetryPolicy struct {
+	// MaxRetries maps a Notion error code to the maximum number of retries
+	// for that code. Codes that are not present are never retried.
+	MaxRetries map[string]int
+	// BaseDelay is the backoff before the first retry. It doubles with every
+	// subsequent attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, including delays requested via Retry-After.
+	// Zero means no cap.
+	MaxDelay time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be retried on
+	// server errors. Rate limited requests are always retried because Notion
+	// rejects them before doing any work.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for most integrations
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: map[string]int{
+			ErrorCodeRateLimited:         5,
+			ErrorCodeInternalServerError: 2,
+			ErrorCodeBadGateway:          3,
+			ErrorCodeServiceUnavailable:  3,
+			ErrorCodeGatewayTimeout:      3,
+		},
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  30 * time.Second,
+	}
+}
+
+// RetryMiddleware retries failed requests according to policy. Each attempt
+// is passed to the next handler with the same request, so the body is encoded
+// afresh every time.
+func RetryMiddleware(policy *RetryPolicy) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			for attempt := 0; ; attempt++ {
+				resp, err := next(ctx, req)
+				if err != nil || resp.StatusCode < 400 {
+					return resp, err
+				}
+
+				code := peekErrorCode(resp)
+				if !policy.shouldRetry(req.Method, code, attempt) {
+					return resp, nil
+				}
+
+				delay := policy.backoff(attempt, resp.Header.Get("Retry-After"))
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+
+				if err := sleepContext(ctx, delay); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// shouldRetry reports whether a request that failed with code should be sent again
+func (p *RetryPolicy) shouldRetry(method, code string, attempt int) bool {
+	if attempt >= p.MaxRetries[code] {
+		return false
+	}
+	if code == ErrorCodeRateLimited || p.RetryNonIdempotent {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A valid
+// Retry-After header takes precedence over the jittered exponential delay.
+func (p *RetryPolicy) backoff(attempt int, retryAfter string) time.Duration {
+	if delay, ok := parseRetryAfter(retryAfter); ok {
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+		return delay
+	}
+
+	delay := p.BaseDelay << uint(attempt)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait between half and the full delay
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// peekErrorCode reads the Notion error code from an error response without
+// consuming its body. Responses without a JSON error body fall back to a code
+// derived from the HTTP status.
+func peekErrorCode(resp *http.Response) string {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err == nil {
+		var apiErr Error
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Code != "" {
+			return apiErr.Code
+		}
+	}
+
+	return codeForStatus(resp.StatusCode)
+}
+
+// sleepContext waits for the given duration or until the context is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
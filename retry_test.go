@@ -0,0 +1,145 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryRateLimitedWithRetryAfter(t *testing.T) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"object":"error","status":429,"code":"rate_limited","message":"slow down"}`))
+			return
+		}
+		w.Write([]byte(`{"object":"page","id":"page-id"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+	page, err := client.UpdatePage(context.Background(), "page-id", &UpdatePageRequest{
+		Properties: map[string]PageProperty{"Name": NewTitleProperty([]RichText{NewText("x")})},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if page.ID != "page-id" {
+		t.Errorf("Expected page ID 'page-id', got '%s'", page.ID)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	for i, body := range bodies {
+		if body != bodies[0] || body == "" {
+			t.Errorf("Expected attempt %d to resend the request body, got '%s'", i+1, body)
+		}
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"object":"error","status":503,"code":"service_unavailable","message":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxRetries = map[string]int{"service_unavailable": 2}
+	client := NewClient("key", WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	_, err := client.GetPage(context.Background(), "page-id")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "service_unavailable" {
+		t.Fatalf("Expected service_unavailable error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetrySkipsNonIdempotentServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html>bad gateway</html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL), WithRetryPolicy(testRetryPolicy()))
+
+	if _, err := client.CreatePage(context.Background(), &CreatePageRequest{Parent: NewPageParent("p")}); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST to be sent once, got %d", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	if _, err := client.GetPage(context.Background(), "page-id"); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if calls != 4 {
+		t.Errorf("Expected GET to be sent 4 times, got %d", calls)
+	}
+}
+
+func TestRetryRespectsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"object":"error","status":429,"code":"rate_limited","message":"slow down"}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	client := NewClient("key", WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetPage(ctx, "page-id")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context deadline error, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("Expected retry wait to stop when the context was cancelled")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("Expected 3s, got %v (%v)", d, ok)
+	}
+	if _, ok := parseRetryAfter(""); ok {
+		t.Error("Expected empty header to be ignored")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Expected invalid header to be ignored")
+	}
+	future := time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(future); !ok || d <= 0 || d > 2*time.Second {
+		t.Errorf("Expected HTTP date to parse to a positive delay, got %v (%v)", d, ok)
+	}
+}
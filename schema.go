@@ -0,0 +1,417 @@
+package notion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// SchemaChangeKind is the kind of a database schema change
+type SchemaChangeKind string
+
+// Database schema change kinds
+const (
+	SchemaAddProperty    SchemaChangeKind = "add_property"
+	SchemaRenameProperty SchemaChangeKind = "rename_property"
+	SchemaRetypeProperty SchemaChangeKind = "retype_property"
+	SchemaRemoveProperty SchemaChangeKind = "remove_property"
+	SchemaAddOption      SchemaChangeKind = "add_option"
+)
+
+// SchemaChange is a single change in a schema migration plan
+type SchemaChange struct {
+	Kind SchemaChangeKind
+	// PropertyID is the ID of the existing property. It is empty for added
+	// properties.
+	PropertyID string
+	// Name is the current name of the property, or the name of an added one
+	Name string
+	// NewName is the name a renamed property gets
+	NewName string
+	// OldType and NewType are the types of a retyped property
+	OldType string
+	NewType string
+	// Option is the name of an added select, multi-select or status option
+	Option string
+	// Property is the desired definition of the property. For added options
+	// it holds every option the property ends up with.
+	Property DatabaseProperty
+}
+
+func (c SchemaChange) String() string {
+	switch c.Kind {
+	case SchemaAddProperty:
+		return fmt.Sprintf("+ add property %q (%s)", c.Name, c.NewType)
+	case SchemaRenameProperty:
+		return fmt.Sprintf("~ rename property %q to %q", c.Name, c.NewName)
+	case SchemaRetypeProperty:
+		return fmt.Sprintf("~ change type of property %q from %s to %s", c.Name, c.OldType, c.NewType)
+	case SchemaRemoveProperty:
+		return fmt.Sprintf("- remove property %q (%s)", c.Name, c.OldType)
+	case SchemaAddOption:
+		return fmt.Sprintf("+ add %s option %q to property %q", c.NewType, c.Option, c.Name)
+	}
+	return string(c.Kind)
+}
+
+// SchemaPlan is the list of changes that turn the schema of a database into
+// a desired schema
+type SchemaPlan struct {
+	DatabaseID string
+	Changes    []SchemaChange
+}
+
+// Empty reports whether the database already has the desired schema
+func (p *SchemaPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p *SchemaPlan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("Database %s: no changes\n", p.DatabaseID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Database %s: %d changes\n", p.DatabaseID, len(p.Changes))
+	for _, change := range p.Changes {
+		b.WriteString("  " + change.String() + "\n")
+	}
+	return b.String()
+}
+
+// Request returns the UpdateDatabaseRequest that applies the plan. Existing
+// properties are addressed by ID, so renames do not affect other changes.
+func (p *SchemaPlan) Request() *UpdateDatabaseRequest {
+	req := &UpdateDatabaseRequest{Properties: map[string]DatabaseProperty{}}
+	for _, change := range p.Changes {
+		key := change.PropertyID
+		if key == "" {
+			key = change.Name
+		}
+		entry := req.Properties[key]
+
+		switch change.Kind {
+		case SchemaAddProperty:
+			entry = change.Property
+			entry.ID = ""
+			entry.Name = change.Name
+			entry.Type = change.NewType
+		case SchemaRenameProperty:
+			entry.Name = change.NewName
+		case SchemaRetypeProperty:
+			name := entry.Name
+			entry = change.Property
+			entry.ID = ""
+			entry.Name = name
+			entry.Type = change.NewType
+		case SchemaAddOption:
+			entry.Type = change.NewType
+			entry.Select = change.Property.Select
+			entry.MultiSelect = change.Property.MultiSelect
+			entry.Status = change.Property.Status
+		case SchemaRemoveProperty:
+			req.RemoveProperties = append(req.RemoveProperties, key)
+			continue
+		}
+		req.Properties[key] = entry
+	}
+	if len(req.Properties) == 0 {
+		req.Properties = nil
+	}
+	return req
+}
+
+// DiffSchema compares the schema of a database with a desired schema keyed
+// by property name. Desired properties with an ID are matched to the
+// existing property with that ID, so a different name is a rename; others
+// are matched by name. Existing properties missing from the desired schema
+// are removed. Only the type and the select, multi-select and status options
+// of a property are compared.
+func DiffSchema(db *Database, desired map[string]DatabaseProperty) (*SchemaPlan, error) {
+	plan := &SchemaPlan{DatabaseID: db.ID}
+
+	byID := map[string]string{}
+	for name, property := range db.Properties {
+		byID[property.ID] = name
+	}
+
+	// Match desired properties to existing ones
+	names := sortedKeys(desired)
+	matched := map[string]string{} // desired name to existing name
+	used := map[string]bool{}
+	for _, name := range names {
+		property := desired[name]
+		if property.ID != "" {
+			existing, ok := byID[property.ID]
+			if !ok {
+				return nil, fmt.Errorf("notion: property %q has ID %q, which is not in database %s", name, property.ID, db.ID)
+			}
+			if used[existing] {
+				return nil, fmt.Errorf("notion: property ID %q is used by more than one desired property", property.ID)
+			}
+			matched[name], used[existing] = existing, true
+		}
+	}
+	for _, name := range names {
+		if _, ok := matched[name]; ok || desired[name].ID != "" {
+			continue
+		}
+		if _, ok := db.Properties[name]; ok && !used[name] {
+			matched[name], used[name] = name, true
+		}
+	}
+
+	// A database has exactly one title property, so an unmatched desired
+	// title renames the existing one
+	currentTitle := ""
+	for name, property := range db.Properties {
+		if property.Type == PropertyTypeTitle {
+			currentTitle = name
+		}
+	}
+	desiredTitle := ""
+	for _, name := range names {
+		if propertyType(desired[name]) == PropertyTypeTitle {
+			if desiredTitle != "" {
+				return nil, fmt.Errorf("notion: desired schema has more than one title property")
+			}
+			desiredTitle = name
+		}
+	}
+	if desiredTitle == "" {
+		return nil, fmt.Errorf("notion: desired schema has no title property")
+	}
+	if _, ok := matched[desiredTitle]; !ok && currentTitle != "" && !used[currentTitle] {
+		matched[desiredTitle], used[currentTitle] = currentTitle, true
+	}
+
+	for _, name := range names {
+		want := desired[name]
+		wantType := propertyType(want)
+		if wantType == "" {
+			return nil, fmt.Errorf("notion: property %q has no type", name)
+		}
+
+		existingName, ok := matched[name]
+		if !ok {
+			plan.Changes = append(plan.Changes, SchemaChange{Kind: SchemaAddProperty, Name: name, NewType: wantType, Property: want})
+			continue
+		}
+		current := db.Properties[existingName]
+		change := SchemaChange{PropertyID: current.ID, Name: existingName, OldType: current.Type, NewType: wantType, Property: want}
+
+		if existingName != name {
+			rename := change
+			rename.Kind = SchemaRenameProperty
+			rename.NewName = name
+			plan.Changes = append(plan.Changes, rename)
+		}
+		if current.Type != wantType {
+			if current.Type == PropertyTypeTitle || wantType == PropertyTypeTitle {
+				return nil, fmt.Errorf("notion: cannot change the type of property %q from %s to %s", name, current.Type, wantType)
+			}
+			change.Kind = SchemaRetypeProperty
+			plan.Changes = append(plan.Changes, change)
+			continue
+		}
+		plan.Changes = append(plan.Changes, optionChanges(change, current, want)...)
+	}
+
+	for _, name := range sortedKeys(db.Properties) {
+		if used[name] {
+			continue
+		}
+		current := db.Properties[name]
+		if current.Type == PropertyTypeTitle {
+			return nil, fmt.Errorf("notion: cannot remove title property %q", name)
+		}
+		plan.Changes = append(plan.Changes, SchemaChange{Kind: SchemaRemoveProperty, PropertyID: current.ID, Name: name, OldType: current.Type})
+	}
+	return plan, nil
+}
+
+// optionChanges returns the select, multi-select or status options in want
+// that current lacks. The Property of each change lists the existing options
+// followed by the added ones, since Notion removes options left out.
+func optionChanges(change SchemaChange, current, want DatabaseProperty) []SchemaChange {
+	var existing, wanted []SelectOption
+	switch current.Type {
+	case PropertyTypeSelect:
+		if current.Select != nil {
+			existing = current.Select.Options
+		}
+		if want.Select != nil {
+			wanted = want.Select.Options
+		}
+	case PropertyTypeMultiSelect:
+		if current.MultiSelect != nil {
+			existing = current.MultiSelect.Options
+		}
+		if want.MultiSelect != nil {
+			wanted = want.MultiSelect.Options
+		}
+	case PropertyTypeStatus:
+		if current.Status != nil {
+			for _, option := range current.Status.Options {
+				existing = append(existing, SelectOption(option))
+			}
+		}
+		if want.Status != nil {
+			for _, option := range want.Status.Options {
+				wanted = append(wanted, SelectOption(option))
+			}
+		}
+	default:
+		return nil
+	}
+
+	have := map[string]bool{}
+	for _, option := range existing {
+		have[option.Name] = true
+	}
+	options := append([]SelectOption{}, existing...)
+	var added []string
+	for _, option := range wanted {
+		if !have[option.Name] {
+			have[option.Name] = true
+			options = append(options, SelectOption{Name: option.Name, Color: option.Color})
+			added = append(added, option.Name)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	property := DatabaseProperty{Type: current.Type}
+	switch current.Type {
+	case PropertyTypeSelect:
+		property.Select = &SelectProperty{Options: options}
+	case PropertyTypeMultiSelect:
+		property.MultiSelect = &MultiSelectProperty{Options: options}
+	case PropertyTypeStatus:
+		statusOptions := make([]StatusOption, len(options))
+		for i, option := range options {
+			statusOptions[i] = StatusOption(option)
+		}
+		property.Status = &StatusProperty{Options: statusOptions}
+	}
+
+	changes := make([]SchemaChange, len(added))
+	for i, name := range added {
+		changes[i] = change
+		changes[i].Kind = SchemaAddOption
+		changes[i].Option = name
+		changes[i].Property = property
+	}
+	return changes
+}
+
+// propertyType returns the type of a property, inferring it from the
+// configuration when Type is not set
+func propertyType(p DatabaseProperty) string {
+	if p.Type != "" {
+		return p.Type
+	}
+	configs := []struct {
+		set bool
+		typ string
+	}{
+		{p.Title != nil, PropertyTypeTitle},
+		{p.RichText != nil, PropertyTypeRichText},
+		{p.Number != nil, PropertyTypeNumber},
+		{p.Select != nil, PropertyTypeSelect},
+		{p.MultiSelect != nil, PropertyTypeMultiSelect},
+		{p.Status != nil, PropertyTypeStatus},
+		{p.Date != nil, PropertyTypeDate},
+		{p.People != nil, PropertyTypePeople},
+		{p.Files != nil, PropertyTypeFiles},
+		{p.Checkbox != nil, PropertyTypeCheckbox},
+		{p.URL != nil, PropertyTypeURL},
+		{p.Email != nil, PropertyTypeEmail},
+		{p.PhoneNumber != nil, PropertyTypePhoneNumber},
+		{p.Formula != nil, PropertyTypeFormula},
+		{p.Relation != nil, PropertyTypeRelation},
+		{p.Rollup != nil, PropertyTypeRollup},
+		{p.CreatedTime != nil, PropertyTypeCreatedTime},
+		{p.CreatedBy != nil, PropertyTypeCreatedBy},
+		{p.LastEditedTime != nil, PropertyTypeLastEditedTime},
+		{p.LastEditedBy != nil, PropertyTypeLastEditedBy},
+	}
+	for _, config := range configs {
+		if config.set {
+			return config.typ
+		}
+	}
+	return ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiffDatabaseSchema retrieves a database and compares its schema with a
+// desired schema
+func (c *Client) DiffDatabaseSchema(ctx context.Context, databaseID string, desired map[string]DatabaseProperty) (*SchemaPlan, error) {
+	db, err := c.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSchema(db, desired)
+}
+
+// ApplySchemaPlan applies a schema plan with UpdateDatabase. An empty plan
+// is not sent and returns nil.
+func (c *Client) ApplySchemaPlan(ctx context.Context, plan *SchemaPlan) (*Database, error) {
+	if plan.Empty() {
+		return nil, nil
+	}
+	db, err := c.UpdateDatabase(ctx, plan.DatabaseID, plan.Request())
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply schema plan: %w", err)
+	}
+	return db, nil
+}
+
+// MigrateOptions configures MigrateDatabaseSchema
+type MigrateOptions struct {
+	// DryRun prints the plan without applying it
+	DryRun bool
+	// Output receives the plan in dry-run mode. Nil means os.Stdout.
+	Output io.Writer
+}
+
+// MigrateDatabaseSchema brings the schema of a database in line with a
+// desired schema and returns the plan it applied, or would apply in dry-run
+// mode
+func (c *Client) MigrateDatabaseSchema(ctx context.Context, databaseID string, desired map[string]DatabaseProperty, opts *MigrateOptions) (*SchemaPlan, error) {
+	if opts == nil {
+		opts = &MigrateOptions{}
+	}
+	plan, err := c.DiffDatabaseSchema(ctx, databaseID, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		output := opts.Output
+		if output == nil {
+			output = os.Stdout
+		}
+		if _, err := io.WriteString(output, plan.String()); err != nil {
+			return nil, fmt.Errorf("failed to print schema plan: %w", err)
+		}
+		return plan, nil
+	}
+
+	if _, err := c.ApplySchemaPlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
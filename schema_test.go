@@ -0,0 +1,133 @@
+package notion
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func schemaDatabase() *Database {
+	return &Database{
+		ID: "db-1",
+		Properties: map[string]DatabaseProperty{
+			"Name":     {ID: "title", Name: "Name", Type: PropertyTypeTitle, Title: map[string]interface{}{}},
+			"Priority": {ID: "p1", Name: "Priority", Type: PropertyTypeSelect, Select: &SelectProperty{Options: []SelectOption{{ID: "o1", Name: "High"}}}},
+			"Notes":    {ID: "n1", Name: "Notes", Type: PropertyTypeRichText, RichText: map[string]interface{}{}},
+			"Old":      {ID: "x1", Name: "Old", Type: PropertyTypeCheckbox, Checkbox: map[string]interface{}{}},
+		},
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	plan, err := DiffSchema(schemaDatabase(), map[string]DatabaseProperty{
+		"Task":     {Title: map[string]interface{}{}},
+		"Priority": {Select: &SelectProperty{Options: []SelectOption{{Name: "High"}, {Name: "Low", Color: "gray"}}}},
+		"Summary":  {ID: "n1", Type: PropertyTypeNumber, Number: &NumberProperty{Format: "number"}},
+		"Due":      {Date: map[string]interface{}{}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []string{
+		`+ add property "Due" (date)`,
+		`+ add select option "Low" to property "Priority"`,
+		`~ rename property "Notes" to "Summary"`,
+		`~ change type of property "Notes" from rich_text to number`,
+		`~ rename property "Name" to "Task"`,
+		`- remove property "Old" (checkbox)`,
+	}
+	if len(plan.Changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got:\n%s", len(expected), plan)
+	}
+	for i, change := range plan.Changes {
+		if change.String() != expected[i] {
+			t.Errorf("Expected change %d to be %s, got %s", i, expected[i], change)
+		}
+	}
+
+	data, err := json.Marshal(plan.Request())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var body struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checks := map[string]string{
+		"Due":   `{"name":"Due","type":"date","date":{}}`,
+		"p1":    `{"type":"select","select":{"options":[{"id":"o1","name":"High"},{"name":"Low","color":"gray"}]}}`,
+		"n1":    `{"name":"Summary","type":"number","number":{"format":"number"}}`,
+		"title": `{"name":"Task"}`,
+		"x1":    `null`,
+	}
+	for key, want := range checks {
+		if got := canonicalJSON(t, body.Properties[key]); got != canonicalJSON(t, []byte(want)) {
+			t.Errorf("Expected property %s to be %s, got %s", key, want, body.Properties[key])
+		}
+	}
+}
+
+// canonicalJSON re-encodes data so that object keys are sorted
+func canonicalJSON(t *testing.T, data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	out, _ := json.Marshal(v)
+	return string(out)
+}
+
+func TestDiffSchemaNoChanges(t *testing.T) {
+	db := schemaDatabase()
+	plan, err := DiffSchema(db, db.Properties)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !plan.Empty() || !strings.Contains(plan.String(), "no changes") {
+		t.Errorf("Expected an empty plan, got:\n%s", plan)
+	}
+}
+
+func TestDiffSchemaErrors(t *testing.T) {
+	title := DatabaseProperty{Title: map[string]interface{}{}}
+	tests := map[string]map[string]DatabaseProperty{
+		"no title property":             {"Notes": {RichText: map[string]interface{}{}}},
+		"more than one title property":  {"Name": title, "Other": title},
+		"which is not in database":      {"Name": title, "Ghost": {ID: "zz", Checkbox: map[string]interface{}{}}},
+		"cannot change the type":        {"Name": title, "Notes": {ID: "title", RichText: map[string]interface{}{}}},
+		"has no type":                   {"Name": title, "Mystery": {}},
+		"used by more than one desired": {"Name": title, "A": {ID: "n1", RichText: map[string]interface{}{}}, "B": {ID: "n1", RichText: map[string]interface{}{}}},
+	}
+	for message, desired := range tests {
+		_, err := DiffSchema(schemaDatabase(), desired)
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("Expected an error containing %q, got %v", message, err)
+		}
+	}
+}
+
+func TestDatabasePropertyJSON(t *testing.T) {
+	data, err := json.Marshal(DatabaseProperty{ID: "p1", Name: "Priority", Type: PropertyTypeNumber, Number: &NumberProperty{Format: "number"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := `{"id":"p1","name":"Priority","type":"number","number":{"format":"number"}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	data, err = json.Marshal(UpdateDatabaseRequest{Properties: map[string]DatabaseProperty{
+		"p1":  {Name: "Points"},
+		"Due": {Type: PropertyTypeDate},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected = `{"properties":{"Due":{"date":{},"type":"date"},"p1":{"name":"Points"}}}`
+	if string(data) != expected {
+		t.Errorf("Expected empty fields to be left out of updates, got %s", data)
+	}
+}
@@ -2,6 +2,7 @@ package notion
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 )
 
@@ -32,11 +33,52 @@ type SearchResponse struct {
 	Results []SearchResult `json:"results"`
 }
 
-// SearchResult represents a search result item
+// SearchResult represents a search result item. The API returns pages and
+// databases directly, so exactly one of Page or Database is set depending on
+// Object.
 type SearchResult struct {
 	Object   string    `json:"object"`
 	Page     *Page     `json:"page,omitempty"`
 	Database *Database `json:"database,omitempty"`
+	// Raw holds the result exactly as returned by the API
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a search result into a Page or Database based on its object type
+func (r *SearchResult) UnmarshalJSON(data []byte) error {
+	var obj Object
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	*r = SearchResult{
+		Object: obj.Object,
+		Raw:    append(json.RawMessage(nil), data...),
+	}
+
+	switch obj.Object {
+	case ObjectTypePage:
+		r.Page = &Page{}
+		return json.Unmarshal(data, r.Page)
+	case ObjectTypeDatabase:
+		r.Database = &Database{}
+		return json.Unmarshal(data, r.Database)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a search result back into the object the API returned
+func (r SearchResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case r.Page != nil:
+		return json.Marshal(r.Page)
+	case r.Database != nil:
+		return json.Marshal(r.Database)
+	case r.Raw != nil:
+		return r.Raw, nil
+	}
+	return json.Marshal(Object{Object: r.Object})
 }
 
 // Search performs a search across pages and databases
@@ -53,3 +95,71 @@ func (c *Client) Search(ctx context.Context, req *SearchRequest) (*SearchRespons
 
 	return &searchResp, nil
 }
+
+// SearchPages searches for pages only
+func (c *Client) SearchPages(ctx context.Context, req *SearchRequest) (*PagesListResponse, error) {
+	searchResp, err := c.Search(ctx, withObjectFilter(req, ObjectTypePage))
+	if err != nil {
+		return nil, err
+	}
+
+	pages := &PagesListResponse{ListResponse: searchResp.ListResponse}
+	for _, result := range searchResp.Results {
+		if result.Page != nil {
+			pages.Results = append(pages.Results, *result.Page)
+		}
+	}
+
+	return pages, nil
+}
+
+// SearchDatabases searches for databases only
+func (c *Client) SearchDatabases(ctx context.Context, req *SearchRequest) (*DatabasesListResponse, error) {
+	searchResp, err := c.Search(ctx, withObjectFilter(req, ObjectTypeDatabase))
+	if err != nil {
+		return nil, err
+	}
+
+	databases := &DatabasesListResponse{ListResponse: searchResp.ListResponse}
+	for _, result := range searchResp.Results {
+		if result.Database != nil {
+			databases.Results = append(databases.Results, *result.Database)
+		}
+	}
+
+	return databases, nil
+}
+
+// withObjectFilter returns a copy of req that only matches objects of the given type
+func withObjectFilter(req *SearchRequest, objectType string) *SearchRequest {
+	search := SearchRequest{}
+	if req != nil {
+		search = *req
+	}
+	search.Filter = &SearchFilter{
+		Value:    objectType,
+		Property: "object",
+	}
+	return &search
+}
+
+// SearchPaginator returns a paginator over every search result
+func (c *Client) SearchPaginator(req *SearchRequest) *Paginator[SearchResult] {
+	search := SearchRequest{}
+	if req != nil {
+		search = *req
+	}
+	return NewPaginator(func(ctx context.Context, cursor string) ([]SearchResult, ListResponse, error) {
+		search.StartCursor = cursor
+		resp, err := c.Search(ctx, &search)
+		if err != nil {
+			return nil, ListResponse{}, err
+		}
+		return resp.Results, resp.ListResponse, nil
+	})
+}
+
+// SearchAll performs a search and returns every result, handling pagination automatically
+func (c *Client) SearchAll(ctx context.Context, req *SearchRequest) ([]SearchResult, error) {
+	return c.SearchPaginator(req).All(ctx)
+}
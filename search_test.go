@@ -0,0 +1,72 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const searchResponseJSON = `{
+	"object": "list",
+	"results": [
+		{"object": "page", "id": "page-1", "properties": {"title": {"id": "title", "type": "title", "title": [{"type": "text", "plain_text": "Notes"}]}}},
+		{"object": "database", "id": "db-1", "title": [{"type": "text", "plain_text": "Tasks"}]}
+	],
+	"has_more": false
+}`
+
+func TestSearchResultUnmarshal(t *testing.T) {
+	var resp SearchResponse
+	if err := json.Unmarshal([]byte(searchResponseJSON), &resp); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(resp.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(resp.Results))
+	}
+
+	page := resp.Results[0]
+	if page.Object != "page" || page.Page == nil || page.Database != nil {
+		t.Fatalf("Expected first result to be a page, got %+v", page)
+	}
+	if page.Page.ID != "page-1" || page.Page.Properties["title"].Title[0].PlainText != "Notes" {
+		t.Errorf("Expected page 'page-1' titled 'Notes', got %+v", page.Page)
+	}
+
+	database := resp.Results[1]
+	if database.Object != "database" || database.Database == nil || database.Page != nil {
+		t.Fatalf("Expected second result to be a database, got %+v", database)
+	}
+	if database.Database.ID != "db-1" {
+		t.Errorf("Expected database 'db-1', got '%s'", database.Database.ID)
+	}
+	if len(database.Raw) == 0 {
+		t.Error("Expected raw JSON to be kept")
+	}
+}
+
+func TestSearchPagesSetsFilter(t *testing.T) {
+	var filter SearchFilter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Filter != nil {
+			filter = *req.Filter
+		}
+		w.Write([]byte(searchResponseJSON))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", WithBaseURL(server.URL))
+	pages, err := client.SearchPages(context.Background(), &SearchRequest{Query: "notes"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filter.Property != "object" || filter.Value != "page" {
+		t.Errorf("Expected object=page filter, got %+v", filter)
+	}
+	if len(pages.Results) != 1 || pages.Results[0].ID != "page-1" {
+		t.Errorf("Expected only page-1, got %+v", pages.Results)
+	}
+}
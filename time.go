@@ -0,0 +1,146 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Layouts used when building dates from time.Time
+const (
+	dateLayout      = "2006-01-02"
+	dateTimeLayout  = "2006-01-02T15:04:05.000Z07:00"
+	localTimeLayout = "2006-01-02T15:04:05.000"
+)
+
+// ParseTime parses a timestamp, date or date-time in any of the formats
+// returned by Notion. Values without an offset are in UTC.
+func ParseTime(s string) (time.Time, error) {
+	t, err := parseNotionTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("notion: %w", err)
+	}
+	return t, nil
+}
+
+// parseTimestamp parses an optional created_time or last_edited_time value
+func parseTimestamp(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return ParseTime(s)
+}
+
+// CreatedAt returns the creation time of the page
+func (p *Page) CreatedAt() (time.Time, error) {
+	return parseTimestamp(p.CreatedTime)
+}
+
+// LastEditedAt returns the time the page was last edited
+func (p *Page) LastEditedAt() (time.Time, error) {
+	return parseTimestamp(p.LastEditedTime)
+}
+
+// CreatedAt returns the creation time of the database
+func (d *Database) CreatedAt() (time.Time, error) {
+	return parseTimestamp(d.CreatedTime)
+}
+
+// LastEditedAt returns the time the database was last edited
+func (d *Database) LastEditedAt() (time.Time, error) {
+	return parseTimestamp(d.LastEditedTime)
+}
+
+// CreatedAt returns the creation time of the block
+func (b *Block) CreatedAt() (time.Time, error) {
+	return parseTimestamp(b.CreatedTime)
+}
+
+// LastEditedAt returns the time the block was last edited
+func (b *Block) LastEditedAt() (time.Time, error) {
+	return parseTimestamp(b.LastEditedTime)
+}
+
+// CreatedAt returns the creation time of the comment
+func (c *Comment) CreatedAt() (time.Time, error) {
+	return parseTimestamp(c.CreatedTime)
+}
+
+// LastEditedAt returns the time the comment was last edited
+func (c *Comment) LastEditedAt() (time.Time, error) {
+	return parseTimestamp(c.LastEditedTime)
+}
+
+// NewDate creates a date-time from t, keeping its UTC offset
+func NewDate(t time.Time) Date {
+	return Date{Start: t.Format(dateTimeLayout)}
+}
+
+// NewDateOnly creates a date without a time from the calendar day of t
+func NewDateOnly(t time.Time) Date {
+	return Date{Start: t.Format(dateLayout)}
+}
+
+// NewDateInTimeZone creates a date-time in the IANA time zone timeZone, such
+// as "Europe/Berlin". Notion shows the date in that zone and keeps it there
+// across daylight saving changes.
+func NewDateInTimeZone(t time.Time, timeZone string) (Date, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return Date{}, fmt.Errorf("notion: invalid time zone %q: %w", timeZone, err)
+	}
+	return Date{Start: t.In(loc).Format(localTimeLayout), TimeZone: timeZone}, nil
+}
+
+// WithEnd returns a copy of d that ends at end. The end is formatted like
+// the start: as a date only, in the time zone of d, or with its UTC offset.
+// It returns an error when the time zone of d is unknown.
+func (d Date) WithEnd(end time.Time) (Date, error) {
+	switch {
+	case d.IsDateOnly():
+		d.End = end.Format(dateLayout)
+	case d.TimeZone != "":
+		loc, err := time.LoadLocation(d.TimeZone)
+		if err != nil {
+			return Date{}, fmt.Errorf("notion: invalid time zone %q: %w", d.TimeZone, err)
+		}
+		d.End = end.In(loc).Format(localTimeLayout)
+	default:
+		d.End = end.Format(dateTimeLayout)
+	}
+	return d, nil
+}
+
+// IsDateOnly reports whether the date has no time of day
+func (d Date) IsDateOnly() bool {
+	return d.Start != "" && !strings.Contains(d.Start, "T")
+}
+
+// IsRange reports whether the date has an end
+func (d Date) IsRange() bool {
+	return d.End != ""
+}
+
+// StartTime parses the start of the date. Values without an offset are in
+// the time zone of the date, or UTC when it has none. Dates without a time
+// are midnight in that zone.
+func (d Date) StartTime() (time.Time, error) {
+	return d.parse(d.Start)
+}
+
+// EndTime parses the end of the date like StartTime. It returns the zero
+// time when the date is not a range.
+func (d Date) EndTime() (time.Time, error) {
+	if d.End == "" {
+		return time.Time{}, nil
+	}
+	return d.parse(d.End)
+}
+
+func (d Date) parse(s string) (time.Time, error) {
+	t, _, err := parseNotionDate(s, d.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("notion: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,100 @@
+package notion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := map[string]time.Time{
+		"2024-05-01T10:00:00.000Z":      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2024-05-01T10:00:00Z":          time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2024-05-01T10:00:00.123+02:00": time.Date(2024, 5, 1, 8, 0, 0, 123e6, time.UTC),
+		"2024-05-01T10:00:00.000":       time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2024-05-01T10:00":              time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		"2024-05-01":                    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for s, expected := range tests {
+		got, err := ParseTime(s)
+		if err != nil {
+			t.Errorf("%s: expected no error, got %v", s, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v", s, expected, got)
+		}
+	}
+	if _, err := ParseTime("yesterday"); err == nil {
+		t.Error("Expected an error for an invalid time")
+	}
+}
+
+func TestTimestampAccessors(t *testing.T) {
+	page := &Page{CreatedTime: "2024-05-01T10:00:00.000Z"}
+	created, err := page.CreatedAt()
+	if err != nil || !created.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the page creation time, got %v, %v", created, err)
+	}
+	edited, err := page.LastEditedAt()
+	if err != nil || !edited.IsZero() {
+		t.Errorf("Expected a zero time for a missing timestamp, got %v, %v", edited, err)
+	}
+	if _, err := (&Block{LastEditedTime: "bad"}).LastEditedAt(); err == nil {
+		t.Error("Expected an error for an invalid timestamp")
+	}
+}
+
+func TestDateHelpers(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	start := time.Date(2024, 3, 30, 9, 30, 0, 0, berlin)
+	end := start.Add(48 * time.Hour) // across the switch to summer time
+
+	date, err := NewDate(start).WithEnd(end)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if date.Start != "2024-03-30T09:30:00.000+01:00" || date.End != "2024-04-01T10:30:00.000+02:00" {
+		t.Errorf("Expected date-times with offsets, got %+v", date)
+	}
+
+	date, err = NewDateOnly(start).WithEnd(end)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if date.Start != "2024-03-30" || date.End != "2024-04-01" || !date.IsDateOnly() || !date.IsRange() {
+		t.Errorf("Expected a date-only range, got %+v", date)
+	}
+
+	date, err = NewDateInTimeZone(start.UTC(), "Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	date, err = date.WithEnd(end.UTC())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if date.Start != "2024-03-30T09:30:00.000" || date.End != "2024-04-01T10:30:00.000" || date.TimeZone != "Europe/Berlin" {
+		t.Errorf("Expected wall times in Europe/Berlin, got %+v", date)
+	}
+	gotStart, err := date.StartTime()
+	if err != nil || !gotStart.Equal(start) {
+		t.Errorf("Expected the start to parse back to %v, got %v, %v", start, gotStart, err)
+	}
+	gotEnd, err := date.EndTime()
+	if err != nil || !gotEnd.Equal(end) {
+		t.Errorf("Expected the end to parse back to %v, got %v, %v", end, gotEnd, err)
+	}
+
+	if _, err := NewDateInTimeZone(start, "Mars/Olympus"); err == nil {
+		t.Error("Expected an error for an unknown time zone")
+	}
+	if _, err := (Date{Start: "2024-03-30T09:30:00.000", TimeZone: "Mars/Olympus"}).WithEnd(end); err == nil {
+		t.Error("Expected an error for an end in an unknown time zone")
+	}
+	if end, err := (Date{Start: "2024-05-01"}).EndTime(); err != nil || !end.IsZero() {
+		t.Errorf("Expected a zero end for a single date, got %v, %v", end, err)
+	}
+}
@@ -69,6 +69,26 @@ type File struct {
 	ExpiryTime *time.Time `json:"expiry_time,omitempty"`
 }
 
+// PropertyFile represents a file in a files property value. Type is
+// "external" for files linked by URL and "file" for files hosted by Notion.
+type PropertyFile struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	External *File  `json:"external,omitempty"`
+	File     *File  `json:"file,omitempty"`
+}
+
+// URL returns the URL of the file, whether it is external or hosted by Notion
+func (f PropertyFile) URL() string {
+	switch {
+	case f.External != nil:
+		return f.External.URL
+	case f.File != nil:
+		return f.File.URL
+	}
+	return ""
+}
+
 // RichText represents rich text content
 type RichText struct {
 	Type        string       `json:"type"`
@@ -56,6 +56,22 @@ func (c *Client) ListUsers(ctx context.Context, startCursor string, pageSize int
 	return &users, nil
 }
 
+// UsersPaginator returns a paginator over every user in the workspace
+func (c *Client) UsersPaginator(pageSize int) *Paginator[User] {
+	return NewPaginator(func(ctx context.Context, cursor string) ([]User, ListResponse, error) {
+		resp, err := c.ListUsers(ctx, cursor, pageSize)
+		if err != nil {
+			return nil, ListResponse{}, err
+		}
+		return resp.Results, resp.ListResponse, nil
+	})
+}
+
+// ListAllUsers retrieves every user in the workspace, handling pagination automatically
+func (c *Client) ListAllUsers(ctx context.Context) ([]User, error) {
+	return c.UsersPaginator(100).All(ctx)
+}
+
 // GetMe retrieves the current bot user
 func (c *Client) GetMe(ctx context.Context) (*User, error) {
 	resp, err := c.makeRequest(ctx, "GET", "/users/me", nil)
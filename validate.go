@@ -0,0 +1,315 @@
+package notion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Size limits enforced by the Notion API
+const (
+	// MaxRichTextLength is the maximum length of the content of a rich text element
+	MaxRichTextLength = 2000
+	// MaxURLLength is the maximum length of a URL
+	MaxURLLength = 2000
+	// MaxEquationLength is the maximum length of an equation expression
+	MaxEquationLength = 1000
+	// MaxArrayLength is the maximum number of elements in an array, such as
+	// rich text, multi-select options or relations
+	MaxArrayLength = 100
+	// MaxBlocksPerRequest is the maximum number of blocks in a single request
+	MaxBlocksPerRequest = 1000
+	// MaxRequestSize is the maximum size of a request body in bytes
+	MaxRequestSize = 500 * 1000
+)
+
+// Violation describes a single limit exceeded by a request
+type Violation struct {
+	// Path is the JSON path of the offending value, e.g.
+	// children[0].paragraph.rich_text[0].text.content. It is empty for
+	// limits on the request as a whole.
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError reports every violation found by Validate. It matches
+// ErrValidation with errors.Is.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+	return fmt.Sprintf("notion: invalid request: %s", strings.Join(messages, "; "))
+}
+
+// Is reports whether target is ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Validate checks the request against Notion's size limits
+func (r *CreatePageRequest) Validate() error {
+	v := &validator{}
+	v.properties("properties", r.Properties)
+	v.blockList("children", r.Children)
+	v.icon("icon", r.Icon)
+	v.cover("cover", r.Cover)
+	return v.result(r)
+}
+
+// Validate checks the request against Notion's size limits
+func (r *UpdatePageRequest) Validate() error {
+	v := &validator{}
+	v.properties("properties", r.Properties)
+	v.icon("icon", r.Icon)
+	v.cover("cover", r.Cover)
+	return v.result(r)
+}
+
+// Validate checks the request against Notion's size limits
+func (r *AppendBlockChildrenRequest) Validate() error {
+	v := &validator{}
+	v.blockList("children", r.Children)
+	return v.result(r)
+}
+
+// Validate checks the request against Notion's size limits
+func (r *UpdateBlockRequest) Validate() error {
+	v := &validator{}
+	v.blockContent("", &Block{
+		Paragraph:        r.Paragraph,
+		Heading1:         r.Heading1,
+		Heading2:         r.Heading2,
+		Heading3:         r.Heading3,
+		BulletedListItem: r.BulletedListItem,
+		NumberedListItem: r.NumberedListItem,
+		Quote:            r.Quote,
+		ToDo:             r.ToDo,
+		Toggle:           r.Toggle,
+		Template:         r.Template,
+		Equation:         r.Equation,
+		Code:             r.Code,
+		Callout:          r.Callout,
+		Embed:            r.Embed,
+		Bookmark:         r.Bookmark,
+		Image:            r.Image,
+		Video:            r.Video,
+		File:             r.File,
+		PDF:              r.PDF,
+		Audio:            r.Audio,
+	})
+	return v.result(r)
+}
+
+// WithRequestValidation validates requests that have a Validate method
+// before they are sent, failing with a *ValidationError instead of making
+// the call
+func WithRequestValidation() ClientOption {
+	return func(c *Client) {
+		c.validateRequests = true
+	}
+}
+
+// ValidationMiddleware fails requests whose body has a Validate method that
+// returns an error, without sending them
+func ValidationMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*http.Response, error) {
+			if body, ok := req.Body.(interface{ Validate() error }); ok {
+				if err := body.Validate(); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// validator collects violations while walking a request
+type validator struct {
+	violations []Violation
+	blocks     int
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// result checks the limits on the whole request and returns the violations found
+func (v *validator) result(request interface{}) error {
+	if v.blocks > MaxBlocksPerRequest {
+		v.add("", "request has %d blocks, more than the limit of %d", v.blocks, MaxBlocksPerRequest)
+	}
+	if data, err := json.Marshal(request); err == nil && len(data) > MaxRequestSize {
+		v.add("", "request body is %d bytes, more than the limit of %d", len(data), MaxRequestSize)
+	}
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v.violations}
+}
+
+func (v *validator) length(path string, value string, limit int) {
+	if n := len([]rune(value)); n > limit {
+		v.add(path, "length %d exceeds the limit of %d", n, limit)
+	}
+}
+
+func (v *validator) count(path string, n int) {
+	if n > MaxArrayLength {
+		v.add(path, "%d elements exceed the limit of %d", n, MaxArrayLength)
+	}
+}
+
+func (v *validator) richText(path string, richText []RichText) {
+	v.count(path, len(richText))
+	for i, rt := range richText {
+		itemPath := path + "[" + strconv.Itoa(i) + "]"
+		if rt.Text != nil {
+			v.length(itemPath+".text.content", rt.Text.Content, MaxRichTextLength)
+			if rt.Text.Link != nil {
+				v.length(itemPath+".text.link.url", rt.Text.Link.URL, MaxURLLength)
+			}
+		}
+		if rt.Equation != nil {
+			v.length(itemPath+".equation.expression", rt.Equation.Expression, MaxEquationLength)
+		}
+	}
+}
+
+func (v *validator) blockList(path string, blocks []Block) {
+	v.count(path, len(blocks))
+	for i := range blocks {
+		itemPath := path + "[" + strconv.Itoa(i) + "]"
+		block := &blocks[i]
+		v.blocks++
+		v.blockContent(itemPath, block)
+		if block.Type != BlockTypeChildPage {
+			if children := block.Children(); len(children) > 0 {
+				v.blockList(itemPath+"."+block.Type+".children", children)
+			}
+		}
+	}
+}
+
+// blockContent checks the type specific content of a block, but not its children
+func (v *validator) blockContent(path string, b *Block) {
+	if path != "" {
+		path += "."
+	}
+	check := func(name string, richText []RichText) {
+		v.richText(path+name+".rich_text", richText)
+	}
+	switch {
+	case b.Paragraph != nil:
+		check("paragraph", b.Paragraph.RichText)
+	case b.Heading1 != nil:
+		check("heading_1", b.Heading1.RichText)
+	case b.Heading2 != nil:
+		check("heading_2", b.Heading2.RichText)
+	case b.Heading3 != nil:
+		check("heading_3", b.Heading3.RichText)
+	case b.BulletedListItem != nil:
+		check("bulleted_list_item", b.BulletedListItem.RichText)
+	case b.NumberedListItem != nil:
+		check("numbered_list_item", b.NumberedListItem.RichText)
+	case b.Quote != nil:
+		check("quote", b.Quote.RichText)
+	case b.ToDo != nil:
+		check("to_do", b.ToDo.RichText)
+	case b.Toggle != nil:
+		check("toggle", b.Toggle.RichText)
+	case b.Template != nil:
+		check("template", b.Template.RichText)
+	case b.Callout != nil:
+		check("callout", b.Callout.RichText)
+		v.icon(path+"callout.icon", b.Callout.Icon)
+	case b.Code != nil:
+		check("code", b.Code.RichText)
+		v.richText(path+"code.caption", b.Code.Caption)
+	case b.Equation != nil:
+		v.length(path+"equation.expression", b.Equation.Expression, MaxEquationLength)
+	case b.Bookmark != nil:
+		v.length(path+"bookmark.url", b.Bookmark.URL, MaxURLLength)
+		v.richText(path+"bookmark.caption", b.Bookmark.Caption)
+	case b.Embed != nil:
+		v.length(path+"embed.url", b.Embed.URL, MaxURLLength)
+		v.richText(path+"embed.caption", b.Embed.Caption)
+	case b.LinkPreview != nil:
+		v.length(path+"link_preview.url", b.LinkPreview.URL, MaxURLLength)
+	case b.TableRow != nil:
+		v.count(path+"table_row.cells", len(b.TableRow.Cells))
+		for i, cell := range b.TableRow.Cells {
+			v.richText(path+"table_row.cells["+strconv.Itoa(i)+"]", cell)
+		}
+	case b.Image != nil:
+		v.file(path+"image", b.Image)
+	case b.Video != nil:
+		v.file(path+"video", b.Video)
+	case b.File != nil:
+		v.file(path+"file", b.File)
+	case b.PDF != nil:
+		v.file(path+"pdf", b.PDF)
+	case b.Audio != nil:
+		v.file(path+"audio", b.Audio)
+	}
+}
+
+func (v *validator) file(path string, file *FileBlock) {
+	if file.External != nil {
+		v.length(path+".external.url", file.External.URL, MaxURLLength)
+	}
+	v.richText(path+".caption", file.Caption)
+}
+
+func (v *validator) icon(path string, icon *Icon) {
+	if icon != nil && icon.External != nil {
+		v.length(path+".external.url", icon.External.URL, MaxURLLength)
+	}
+}
+
+func (v *validator) cover(path string, cover *Cover) {
+	if cover != nil && cover.External != nil {
+		v.length(path+".external.url", cover.External.URL, MaxURLLength)
+	}
+}
+
+func (v *validator) properties(path string, properties map[string]PageProperty) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := properties[name]
+		propertyPath := path + "[" + strconv.Quote(name) + "]"
+		v.richText(propertyPath+".title", property.Title)
+		v.richText(propertyPath+".rich_text", property.RichText)
+		v.count(propertyPath+".multi_select", len(property.MultiSelect))
+		v.count(propertyPath+".relation", len(property.Relation))
+		v.count(propertyPath+".people", len(property.People))
+		v.count(propertyPath+".files", len(property.Files))
+		for i, file := range property.Files {
+			if file.External != nil {
+				v.length(propertyPath+".files["+strconv.Itoa(i)+"].external.url", file.External.URL, MaxURLLength)
+			}
+		}
+		v.length(propertyPath+".url", property.URL, MaxURLLength)
+	}
+}
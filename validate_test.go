@@ -0,0 +1,128 @@
+package notion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAppendBlockChildrenRequestValidate(t *testing.T) {
+	long := strings.Repeat("a", MaxRichTextLength+1)
+	richText := make([]RichText, MaxArrayLength+1)
+	for i := range richText {
+		richText[i] = NewText("x")
+	}
+
+	req := &AppendBlockChildrenRequest{Children: []Block{
+		*NewParagraphBlock([]RichText{NewText("ok"), NewText(long)}),
+		*NewToggleBlock(nil).WithChildren(
+			NewEquationBlock(strings.Repeat("x", MaxEquationLength+1)),
+			NewBookmarkBlock("https://example.com/"+long),
+		),
+		*NewQuoteBlock(richText),
+	}}
+
+	err := req.Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *ValidationError, got %v", err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("Expected the error to match ErrValidation")
+	}
+
+	expected := []string{
+		"children[0].paragraph.rich_text[1].text.content",
+		"children[1].toggle.children[0].equation.expression",
+		"children[1].toggle.children[1].bookmark.url",
+		"children[2].quote.rich_text",
+	}
+	var paths []string
+	for _, v := range validationErr.Violations {
+		paths = append(paths, v.Path)
+	}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected violations at %v, got %v", expected, validationErr.Violations)
+	}
+	if !strings.Contains(err.Error(), "length 2001 exceeds the limit of 2000") {
+		t.Errorf("Expected the length in the message, got %s", err.Error())
+	}
+}
+
+func TestValidateBlockCount(t *testing.T) {
+	var children []Block
+	for i := 0; i < 11; i++ {
+		toggle := NewToggleBlock(nil)
+		for j := 0; j < 99; j++ {
+			toggle.WithChildren(NewDividerBlock())
+		}
+		children = append(children, *toggle)
+	}
+
+	err := (&AppendBlockChildrenRequest{Children: children}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "request has 1100 blocks") {
+		t.Errorf("Expected a block count violation, got %v", err)
+	}
+}
+
+func TestPageRequestValidate(t *testing.T) {
+	valid := &CreatePageRequest{
+		Parent: NewDatabaseParent("db"),
+		Properties: map[string]PageProperty{
+			"Name": NewTitleProperty([]RichText{NewText("Title")}),
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	update := &UpdatePageRequest{
+		Properties: map[string]PageProperty{
+			"Link": NewURLProperty("https://example.com/" + strings.Repeat("a", MaxURLLength)),
+			"Tags": NewMultiSelectProperty(make([]SelectOption, MaxArrayLength+1)),
+		},
+		Icon: NewExternalFileIcon("https://example.com/" + strings.Repeat("a", MaxURLLength)),
+	}
+	err := update.Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Violations) != 3 {
+		t.Fatalf("Expected 3 violations, got %v", err)
+	}
+	if path := validationErr.Violations[0].Path; path != `properties["Link"].url` {
+		t.Errorf(`Expected path properties["Link"].url, got %s`, path)
+	}
+
+	block := &UpdateBlockRequest{Code: &CodeBlock{RichText: []RichText{NewText(strings.Repeat("a", MaxRichTextLength+1))}}}
+	if err := block.Validate(); err == nil || !strings.HasPrefix(err.Error(), "notion: invalid request: code.rich_text[0].text.content") {
+		t.Errorf("Expected a code content violation, got %v", err)
+	}
+}
+
+func TestWithRequestValidation(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"object":"list","results":[],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	req := &AppendBlockChildrenRequest{Children: []Block{
+		*NewParagraphBlock([]RichText{NewText(strings.Repeat("a", MaxRichTextLength+1))}),
+	}}
+
+	client := NewClient("key", WithBaseURL(server.URL), WithRequestValidation())
+	if _, err := client.AppendBlockChildren(context.Background(), "block", req); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected the invalid request not to be sent, got %d calls", calls)
+	}
+
+	client = NewClient("key", WithBaseURL(server.URL))
+	if _, err := client.AppendBlockChildren(context.Background(), "block", req); err != nil {
+		t.Errorf("Expected validation to be opt-in, got %v", err)
+	}
+}